@@ -0,0 +1,336 @@
+package remapper
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "reflect"
+    "strings"
+    "sync"
+    "sync/atomic"
+)
+
+// ErrorPolicy is a way to handle errors of rows at 'MapAll' and 'MapStream'
+type ErrorPolicy int
+
+const (
+    // StopOnError stops mapping at first row with error in order of rows. Default.
+    StopOnError ErrorPolicy = iota
+
+    // CollectErrors maps all rows and collects errors of rows
+    CollectErrors
+)
+
+// Direction is a direction of mapping of rows at 'MapAll' and 'MapStream'
+type Direction int
+
+const (
+    // AutoDirection resolves direction via type of each row same way as 'Map'. Default.
+    AutoDirection Direction = iota
+
+    // ForwardDirection maps rows of first type of mapper same way as 'Forward', e.g. for mapper between same types
+    ForwardDirection
+
+    // BackwardDirection maps rows of second type of mapper same way as 'Backward'
+    BackwardDirection
+)
+
+// BatchOptions holds settings of 'MapAll' and 'MapStream'
+type BatchOptions struct {
+    // Workers is a number of rows that are mapped concurrently. Default: 1
+    Workers int
+
+    // ErrorPolicy is a way to handle errors of rows. Default: StopOnError
+    ErrorPolicy ErrorPolicy
+
+    // Direction is a direction of mapping of rows. Mapper between same types requires explicit direction. Default: AutoDirection
+    Direction Direction
+}
+
+// RowError is an error of row with index
+type RowError struct {
+    Index int
+    Err   error
+}
+
+func (e *RowError) Error() (string) {
+    return fmt.Sprintf("Could not map row %d. %s", e.Index, e.Err.Error())
+}
+
+func (e *RowError) Unwrap() (error) {
+    return e.Err
+}
+
+// BatchError holds errors of rows that were collected via CollectErrors policy
+type BatchError struct {
+    Errors []*RowError
+}
+
+func (e *BatchError) Error() (string) {
+    messages := make([]string, len(e.Errors))
+    for i, err := range e.Errors {
+        messages[i] = err.Error()
+    }
+
+    return strings.Join(messages, "\n")
+}
+
+// StreamResult is a result of mapping of row with index at 'MapStream'.
+// Result of cancelled mapping has an error of context and index that is a number of processed rows.
+type StreamResult struct {
+    Index int
+    Value interface{}
+    Err   error
+}
+
+// streamJob is a row with index to map and channel to send a result of mapping
+type streamJob struct {
+    index  int
+    value  interface{}
+    result chan StreamResult
+}
+
+// MapContext maps from object to reverse object same way as 'Map', but returns error of ctx if ctx is done
+func (m *Mapper) MapContext(ctx context.Context, from interface{}) (interface{}, error) {
+    if err := ctx.Err(); err != nil {
+        return nil, err
+    }
+
+    return m.Map(from)
+}
+
+// MapAll maps each row of slice 'from' and returns results in same order. Result is nil for row that was not mapped.
+//
+// - with StopOnError policy it returns *RowError of first row with error
+// - with CollectErrors policy it returns results of all rows and *BatchError with errors of rows
+func (m *Mapper) MapAll(from interface{}, options BatchOptions) ([]interface{}, error) {
+    results, _, err := m.MapAllContext(context.Background(), from, options)
+    return results, err
+}
+
+// MapAllContext maps rows same way as 'MapAll', but stops if ctx is done.
+// Returns a number of processed rows and for cancelled mapping results of processed rows with error of ctx.
+func (m *Mapper) MapAllContext(ctx context.Context, from interface{}, options BatchOptions) ([]interface{}, int, error) {
+    rows := reflect.ValueOf(from)
+    if rows.Kind() != reflect.Slice && rows.Kind() != reflect.Array {
+        return nil, 0, errors.New(fmt.Sprintf("Invalid type '%s'. Rows must be a slice or array.", rows.Kind()))
+    }
+
+    if err := ctx.Err(); err != nil {
+        return nil, 0, err
+    }
+
+    //rows are not fed after first error with StopOnError policy, because they will not be mapped
+    stop := make(chan struct{})
+    in := feedRows(ctx, rows, stop)
+
+    results := make([]interface{}, rows.Len())
+    processed := 0
+    var errs []*RowError
+    var ctxErr error
+
+    for result := range m.MapStreamContext(ctx, in, options) {
+        if rowErr, ok := result.Err.(*RowError); ok {
+            if len(errs) == 0 && options.ErrorPolicy == StopOnError {
+                close(stop)
+            }
+
+            errs = append(errs, rowErr)
+        } else if result.Err != nil {
+            ctxErr = result.Err
+            continue
+        } else {
+            results[result.Index] = result.Value
+        }
+
+        processed++
+    }
+
+    if ctxErr != nil {
+        return results[:processed], processed, ctxErr
+    }
+
+    if len(errs) > 0 {
+        if options.ErrorPolicy == StopOnError {
+            return nil, processed, errs[0]
+        }
+
+        return results, processed, &BatchError{Errors: errs}
+    }
+
+    return results, processed, nil
+}
+
+// feedRows sends rows into returned channel until all rows were sent, ctx is done or stop is closed. Channel is closed after that.
+func feedRows(ctx context.Context, rows reflect.Value, stop <-chan struct{}) (<-chan interface{}) {
+    in := make(chan interface{})
+
+    go func() {
+        defer close(in)
+
+        for i, i_max := 0, rows.Len(); i < i_max; i++ {
+            //stop has priority over receiver that is ready
+            select {
+            case <-stop:
+                return
+            default:
+            }
+
+            select {
+            case in <- rows.Index(i).Interface():
+            case <-stop:
+                return
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+
+    return in
+}
+
+// MapStream maps rows from channel 'from' with few workers and sends results in same order as rows were received.
+// Error of row is sent as *RowError. Channel of results is closed after all rows were mapped.
+//
+// - with StopOnError policy it stops mapping after first row with error. Rest of rows are received, but not mapped.
+// - with CollectErrors policy it maps all rows
+func (m *Mapper) MapStream(from <-chan interface{}, options BatchOptions) (<-chan StreamResult) {
+    return m.MapStreamContext(context.Background(), from, options)
+}
+
+// MapStreamContext maps rows same way as 'MapStream', but stops if ctx is done.
+// Last result of cancelled mapping has error of ctx and index that is a number of processed rows.
+// Rest of rows are received and dropped after cancellation, so producer that doesn't select on ctx is not blocked, but it must close 'from'.
+// N.B.: Channel of results must be read until it's closed.
+func (m *Mapper) MapStreamContext(ctx context.Context, from <-chan interface{}, options BatchOptions) (<-chan StreamResult) {
+    workers := options.Workers
+    if workers < 1 {
+        workers = 1
+    }
+
+    out := make(chan StreamResult, workers)
+    jobs := make(chan streamJob)
+    pending := make(chan chan StreamResult, workers)
+    var stopped, cancelled int32
+
+    //workers
+    var wg sync.WaitGroup
+    for i := 0; i < workers; i++ {
+        wg.Add(1)
+
+        go func() {
+            defer wg.Done()
+
+            for job := range jobs {
+                if err := ctx.Err(); err != nil {
+                    job.result <- StreamResult{Index: job.index, Err: err}
+                    continue
+                }
+
+                value, err := m.mapRow(options.Direction, job.value)
+                if err != nil {
+                    err = &RowError{Index: job.index, Err: err}
+                }
+
+                job.result <- StreamResult{Index: job.index, Value: value, Err: err}
+            }
+        }()
+    }
+
+    //dispatcher
+    go func() {
+        defer close(pending)
+        defer close(jobs)
+
+        for i := 0; ; i++ {
+            var value interface{}
+            var ok bool
+
+            select {
+            case value, ok = <-from:
+                //rows can be closed due to cancellation
+                if !ok {
+                    if ctx.Err() != nil {
+                        atomic.StoreInt32(&cancelled, 1)
+                    }
+
+                    return
+                }
+            case <-ctx.Done():
+                atomic.StoreInt32(&cancelled, 1)
+                go drain(from)
+                return
+            }
+
+            if atomic.LoadInt32(&stopped) != 0 {
+                continue
+            }
+
+            result := make(chan StreamResult, 1)
+            select {
+            case pending <- result:
+            case <-ctx.Done():
+                atomic.StoreInt32(&cancelled, 1)
+                go drain(from)
+                return
+            }
+
+            jobs <- streamJob{index: i, value: value, result: result}
+        }
+    }()
+
+    //collector keeps order of rows
+    go func() {
+        defer close(out)
+
+        processed := 0
+        for result := range pending {
+            r := <-result
+            if atomic.LoadInt32(&stopped) != 0 {
+                continue
+            }
+
+            if ctx.Err() == nil {
+                select {
+                case out <- r:
+                    processed++
+                case <-ctx.Done():
+                }
+            }
+
+            if err := ctx.Err(); err != nil {
+                atomic.StoreInt32(&stopped, 1)
+                out <- StreamResult{Index: processed, Err: err}
+            } else if r.Err != nil && options.ErrorPolicy == StopOnError {
+                atomic.StoreInt32(&stopped, 1)
+            }
+        }
+
+        //cancelled while rows were received
+        if atomic.LoadInt32(&cancelled) != 0 && atomic.LoadInt32(&stopped) == 0 {
+            out <- StreamResult{Index: processed, Err: ctx.Err()}
+        }
+
+        wg.Wait()
+    }()
+
+    return out
+}
+
+// drain receives rows until channel is closed
+func drain(from <-chan interface{}) {
+    for range from {
+    }
+}
+
+// mapRow maps from row to reverse object in direction
+func (m *Mapper) mapRow(direction Direction, from interface{}) (interface{}, error) {
+    switch direction {
+    case ForwardDirection:
+        return m.Forward(from)
+    case BackwardDirection:
+        return m.Backward(from)
+    }
+
+    return m.Map(from)
+}
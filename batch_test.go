@@ -0,0 +1,201 @@
+package remapper
+
+import (
+    "testing"
+    "context"
+    "reflect"
+    "strconv"
+    "time"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestMapAll(t *testing.T) {
+    mapper, err := New(TestStructNamed{}, Slice([]string{}, arrayFieldNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    rows := make([][]string, 100)
+    for i := range rows {
+        rows[i] = []string{"", strconv.Itoa(i), "1", "", "test string", "1.2345", "true", ""}
+    }
+
+    results, err := mapper.MapAll(rows, BatchOptions{Workers: 4})
+    require.Nil(t, err)
+    require.Len(t, results, len(rows))
+    for i, result := range results {
+        assert.Equal(t, i, result.(TestStructNamed).IntVal)
+    }
+
+    //stop on first error
+    rows[10][1] = "invalid"
+    rows[20][1] = "invalid"
+
+    results, err = mapper.MapAll(rows, BatchOptions{Workers: 4})
+    assert.Nil(t, results)
+    require.IsType(t, &RowError{}, err)
+    assert.Equal(t, 10, err.(*RowError).Index)
+
+    _, processed, err := mapper.MapAllContext(context.Background(), rows, BatchOptions{})
+    require.IsType(t, &RowError{}, err)
+    assert.Equal(t, 11, processed)
+
+    //rows are not fed after stop, but one row can be sent already
+    stop := make(chan struct{})
+    in := feedRows(context.Background(), reflect.ValueOf(rows), stop)
+    <-in
+    close(stop)
+
+    fed := 0
+    for range in {
+        fed++
+    }
+
+    assert.LessOrEqual(t, fed, 1)
+
+    //collect errors
+    results, err = mapper.MapAll(rows, BatchOptions{Workers: 4, ErrorPolicy: CollectErrors})
+    require.Len(t, results, len(rows))
+    assert.Nil(t, results[10])
+    assert.Equal(t, 11, results[11].(TestStructNamed).IntVal)
+    require.IsType(t, &BatchError{}, err)
+    require.Len(t, err.(*BatchError).Errors, 2)
+    assert.Equal(t, 10, err.(*BatchError).Errors[0].Index)
+    assert.Equal(t, 20, err.(*BatchError).Errors[1].Index)
+
+    _, err = mapper.MapAll(rows[0], BatchOptions{})
+    assert.NotNil(t, err)
+}
+
+func TestMapStream(t *testing.T) {
+    mapper, err := New(TestStructNamed{}, Slice([]string{}, arrayFieldNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    in := make(chan interface{})
+    go func() {
+        defer close(in)
+
+        for i := 0; i < 100; i++ {
+            if i == 50 {
+                in <- []string{"", "invalid"}
+            } else {
+                in <- []string{"", strconv.Itoa(i)}
+            }
+        }
+    }()
+
+    //order of rows is preserved and rest of rows are not mapped after error
+    i := 0
+    for result := range mapper.MapStream(in, BatchOptions{Workers: 8}) {
+        assert.Equal(t, i, result.Index)
+
+        if i == 50 {
+            require.NotNil(t, result.Err)
+            assert.Contains(t, result.Err.Error(), "row 50")
+        } else {
+            require.Nil(t, result.Err)
+            assert.Equal(t, i, result.Value.(TestStructNamed).IntVal)
+        }
+
+        i++
+    }
+
+    assert.Equal(t, 51, i)
+}
+
+func TestMapContext(t *testing.T) {
+    mapper, err := New(TestStructNamed{}, Slice([]string{}, arrayFieldNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+
+    s, err := mapper.MapContext(ctx, arrayTyped)
+    require.Nil(t, err)
+    assert.Equal(t, mappedNamedStruct, s)
+
+    //stream is stopped after cancellation
+    in := make(chan interface{})
+    go func() {
+        defer close(in)
+
+        for i := 0; i < 1000; i++ {
+            select {
+            case in <- []string{"", strconv.Itoa(i)}:
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+
+    mapped := 0
+    var last StreamResult
+    for result := range mapper.MapStreamContext(ctx, in, BatchOptions{Workers: 4}) {
+        if result.Err == nil {
+            assert.Equal(t, mapped, result.Value.(TestStructNamed).IntVal)
+            mapped++
+        }
+
+        if mapped == 10 {
+            cancel()
+        }
+
+        last = result
+    }
+
+    assert.Equal(t, context.Canceled, last.Err)
+    assert.Equal(t, mapped, last.Index)
+    assert.True(t, mapped < 1000)
+
+    //producer that doesn't select on ctx is not blocked after cancellation
+    blockingCtx, blockingCancel := context.WithCancel(context.Background())
+    defer blockingCancel()
+
+    blocking := make(chan interface{})
+    produced := make(chan struct{})
+    go func() {
+        defer close(produced)
+        defer close(blocking)
+
+        for i := 0; i < 1000; i++ {
+            blocking <- []string{"", strconv.Itoa(i)}
+        }
+    }()
+
+    for result := range mapper.MapStreamContext(blockingCtx, blocking, BatchOptions{Workers: 2}) {
+        if result.Err == nil && result.Index == 10 {
+            blockingCancel()
+        }
+    }
+
+    select {
+    case <-produced:
+    case <-time.After(5 * time.Second):
+        t.Fatal("producer is blocked after cancellation")
+    }
+
+    //cancelled context
+    s, err = mapper.MapContext(ctx, arrayTyped)
+    assert.Equal(t, context.Canceled, err)
+    assert.Nil(t, s)
+
+    results, processed, err := mapper.MapAllContext(ctx, [][]string{arrayTyped, arrayTyped}, BatchOptions{})
+    assert.Equal(t, context.Canceled, err)
+    assert.Equal(t, 0, processed)
+    assert.Len(t, results, 0)
+
+    //deadline
+    deadlineCtx, deadlineCancel := context.WithTimeout(context.Background(), 0)
+    defer deadlineCancel()
+
+    _, _, err = mapper.MapAllContext(deadlineCtx, [][]string{arrayTyped, arrayTyped}, BatchOptions{})
+    assert.Equal(t, context.DeadlineExceeded, err)
+
+    //not cancelled
+    results, processed, err = mapper.MapAllContext(context.Background(), [][]string{arrayTyped, arrayTyped}, BatchOptions{Workers: 2})
+    require.Nil(t, err)
+    assert.Equal(t, 2, processed)
+    assert.Equal(t, []interface{}{mappedNamedStruct, mappedNamedStruct}, results)
+}
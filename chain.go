@@ -0,0 +1,126 @@
+package remapper
+
+import (
+    "errors"
+    "fmt"
+)
+
+// MapperChain maps data through few mappers, e.g.: slice -> import struct -> domain struct -> map.
+// Result of each stage is a data for next stage, so adjacent mappers must have same type between them.
+type MapperChain struct {
+    mappers    []*Mapper
+    directions []int //Holds index of type of mapper that is a source of stage for forward direction
+}
+
+// StageError is an error of stage with index, i.e. index of mapper at chain
+type StageError struct {
+    Stage int
+    Err   error
+}
+
+func (e *StageError) Error() (string) {
+    return fmt.Sprintf("Could not map at stage %d. %s", e.Stage, e.Err.Error())
+}
+
+func (e *StageError) Unwrap() (error) {
+    return e.Err
+}
+
+// Chain creates a new chain of mappers. Direction of each mapper is resolved via types of adjacent mappers,
+// e.g. for Chain(m1, m2) second type of m1 or first type of m1 must be same as one of types of m2. Returns *StageError for stage that can't be linked.
+func Chain(mappers ...*Mapper) (*MapperChain, error) {
+    if len(mappers) == 0 {
+        return nil, errors.New("You must provide at least one mapper for chain.")
+    }
+
+    var lastErr *StageError
+    for _, first := range []int{0, 1} {
+        directions, err := chainDirections(mappers, first)
+        if err == nil {
+            return &MapperChain{mappers: mappers, directions: directions}, nil
+        }
+
+        if lastErr == nil || err.Stage > lastErr.Stage {
+            lastErr = err
+        }
+    }
+
+    return nil, lastErr
+}
+
+// chainDirections resolves directions of mappers if first mapper has direction first. Mapper is used in forward direction if both directions are possible.
+func chainDirections(mappers []*Mapper, first int) ([]int, *StageError) {
+    directions := []int{first}
+
+    for i := 1; i < len(mappers); i++ {
+        prev := mappers[i-1]
+        out := prev.types[1-directions[i-1]].normalizedType
+
+        switch {
+        case mappers[i].types[0].normalizedType == out:
+            directions = append(directions, 0)
+        case mappers[i].types[1].normalizedType == out:
+            directions = append(directions, 1)
+        default:
+            return nil, &StageError{Stage: i, Err: errors.New(fmt.Sprintf("Type mismatch. Expected '%s' or '%s', but got '%s'", mappers[i].types[0].normalizedType, mappers[i].types[1].normalizedType, out))}
+        }
+    }
+
+    return directions, nil
+}
+
+// Mappers returns mappers of chain
+func (c *MapperChain) Mappers() ([]*Mapper) {
+    return append([]*Mapper(nil), c.mappers...)
+}
+
+// Map maps from object through all stages. Direction is resolved via type of object, so chain between same types must use 'Forward' or 'Backward'.
+func (c *MapperChain) Map(from interface{}) (interface{}, error) {
+    first := c.mappers[0].types[c.directions[0]]
+    last := c.mappers[len(c.mappers)-1].types[1-c.directions[len(c.mappers)-1]]
+
+    if first.normalizedType == last.normalizedType {
+        return nil, errors.New(fmt.Sprintf("Ambiguous direction for chain between same types '%s'. Use 'Forward' or 'Backward'.", first.normalizedType))
+    }
+
+    if c.mappers[0].checkType(first, from) == nil {
+        return c.Forward(from)
+    }
+
+    return c.Backward(from)
+}
+
+// Forward maps from object of first type of chain through stages in order of mappers.
+// Returns *StageError with index of mapper that failed. Mapping stops with nil result if stage has nothing mapped, see 'WithEmpty'.
+func (c *MapperChain) Forward(from interface{}) (interface{}, error) {
+    for i, mapper := range c.mappers {
+        var err error
+        if from, err = mapper.mapDirection(mapper.types[c.directions[i]], from); err != nil {
+            return nil, &StageError{Stage: i, Err: err}
+        }
+
+        if from == nil {
+            return nil, nil
+        }
+    }
+
+    return from, nil
+}
+
+// Backward maps from object of last type of chain through stages in reverse order of mappers. See 'Forward' for details.
+func (c *MapperChain) Backward(from interface{}) (interface{}, error) {
+    for i := len(c.mappers) - 1; i >= 0; i-- {
+        mapper := c.mappers[i]
+
+        var err error
+        if from, err = mapper.mapDirection(mapper.types[1-c.directions[i]], from); err != nil {
+            return nil, &StageError{Stage: i, Err: err}
+        }
+
+        if from == nil {
+            return nil, nil
+        }
+    }
+
+    return from, nil
+}
@@ -0,0 +1,86 @@
+package remapper
+
+import (
+    "testing"
+    "errors"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+    type TestImport struct {
+        Name string `remapper:"name"`
+        Age  string `remapper:"age"`
+    }
+
+    type TestDomain struct {
+        FullName string
+        Age      int
+    }
+
+    rowMapper, err := New(Slice([]string{}, []string{"name", "age"}), &TestImport{})
+    require.Nil(t, err)
+
+    domainMapper, err := New(&TestImport{}, &TestDomain{}, map[string]string{"Name": "FullName", "Age": "Age"})
+    require.Nil(t, err)
+
+    //mapper in backward direction
+    outputMapper, err := New(map[string]interface{}{}, &TestDomain{})
+    require.Nil(t, err)
+
+    chain, err := Chain(rowMapper, domainMapper, outputMapper)
+    require.Nil(t, err)
+    require.NotNil(t, chain)
+    assert.Len(t, chain.Mappers(), 3)
+
+    m, err := chain.Map([]string{"John", "20"})
+    require.Nil(t, err)
+    assert.Equal(t, map[string]interface{}{"FullName": "John", "Age": 20}, m)
+
+    a, err := chain.Map(map[string]interface{}{"FullName": "John", "Age": "20"})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"John", "20"}, a)
+
+    //errors are reported with stage
+    _, err = chain.Forward([]string{"John", "abc"})
+    var stageErr *StageError
+    require.True(t, errors.As(err, &stageErr))
+    assert.Equal(t, 1, stageErr.Stage)
+
+    _, err = chain.Backward([]string{"John", "20"})
+    require.True(t, errors.As(err, &stageErr))
+    assert.Equal(t, 2, stageErr.Stage)
+
+    //nothing to map
+    m, err = chain.Forward([]string{"", ""})
+    require.Nil(t, err)
+    assert.Nil(t, m)
+
+    //first mapper can be in backward direction too
+    chain, err = Chain(domainMapper, rowMapper)
+    require.Nil(t, err)
+
+    a, err = chain.Forward(TestDomain{FullName: "John", Age: 20})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"John", "20"}, a)
+
+    //adjacent types must be same
+    chain, err = Chain(rowMapper, outputMapper)
+    require.True(t, errors.As(err, &stageErr))
+    assert.Equal(t, 1, stageErr.Stage)
+    assert.Nil(t, chain)
+
+    _, err = Chain()
+    assert.NotNil(t, err)
+
+    //chain between same types, i.e. slice -> struct -> slice
+    chain, err = Chain(rowMapper, rowMapper)
+    require.Nil(t, err)
+
+    _, err = chain.Map([]string{"John", "20"})
+    assert.NotNil(t, err)
+
+    a, err = chain.Forward([]string{" John ", "20"})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"John", "20"}, a)
+}
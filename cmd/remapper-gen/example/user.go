@@ -0,0 +1,24 @@
+// Package example holds a struct with functions that were generated by remapper-gen
+package example
+
+//go:generate go run github.com/plandem/remapper/cmd/remapper-gen user.go
+
+// User is mapped to/from named slice and map
+//remapper:gen slice=id,name,age,score,active,level map
+type User struct {
+    ID     uint64  `remapper:"id"`
+    Name   string  `remapper:"name"`
+    Age    int8    `remapper:"age"`
+    Score  float32 `remapper:"score"`
+    Active bool    `remapper:"active"`
+    Level  int     `remapper:"5"`
+    Email  string
+}
+
+// UserRow is mapped to/from indexed slice, e.g. row of CSV file without header
+//remapper:gen slice
+type UserRow struct {
+    ID   uint64 `remapper:"0"`
+    Name string `remapper:"1"`
+    Note string `remapper:"3,omit"`
+}
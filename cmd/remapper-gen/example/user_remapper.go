@@ -0,0 +1,335 @@
+// Code generated by remapper-gen from user.go. DO NOT EDIT.
+
+package example
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// UserToSlice maps User into slice same way as remapper.Mapper.Map with remapper.Slice([]string{}, []string{"id", "name", "age", "score", "active", "level"}). Returns nil if nothing was mapped.
+func UserToSlice(from *User) ([]string, error) {
+	to := make([]string, 6)
+	mapped := false
+
+	to[0] = strconv.FormatUint(uint64(from.ID), 10)
+	mapped = true
+
+	if v := strings.TrimSpace(from.Name); len(v) > 0 {
+		to[1] = v
+		mapped = true
+	}
+
+	to[2] = strconv.FormatInt(int64(from.Age), 10)
+	mapped = true
+
+	to[3] = strconv.FormatFloat(float64(from.Score), 'f', 4, 32)
+	mapped = true
+
+	to[4] = strconv.FormatBool(from.Active)
+	mapped = true
+
+	to[5] = strconv.FormatInt(int64(from.Level), 10)
+	mapped = true
+
+	if !mapped {
+		return nil, nil
+	}
+
+	return to, nil
+}
+
+// UserFromSlice maps slice into User same way as remapper.Mapper.Map with remapper.Slice([]string{}, []string{"id", "name", "age", "score", "active", "level"}). Returns nil if nothing was mapped.
+func UserFromSlice(from []string) (*User, error) {
+	to := &User{}
+	mapped := false
+
+	if len(from) > 0 {
+		if v, ok, err := remapperUserUint(from[0]); err != nil {
+			return nil, errors.New("Could not convert 'ID'. " + err.Error())
+		} else if ok {
+			to.ID = uint64(v)
+			mapped = true
+		}
+	}
+
+	if len(from) > 1 {
+		if v := strings.TrimSpace(from[1]); len(v) > 0 {
+			to.Name = v
+			mapped = true
+		}
+	}
+
+	if len(from) > 2 {
+		if v, ok, err := remapperUserInt(from[2]); err != nil {
+			return nil, errors.New("Could not convert 'Age'. " + err.Error())
+		} else if ok {
+			to.Age = int8(v)
+			mapped = true
+		}
+	}
+
+	if len(from) > 3 {
+		if v, ok, err := remapperUserFloat(from[3]); err != nil {
+			return nil, errors.New("Could not convert 'Score'. " + err.Error())
+		} else if ok {
+			to.Score = float32(v)
+			mapped = true
+		}
+	}
+
+	if len(from) > 4 {
+		if v, ok, err := remapperUserBool(from[4]); err != nil {
+			return nil, errors.New("Could not convert 'Active'. " + err.Error())
+		} else if ok {
+			to.Active = v
+			mapped = true
+		}
+	}
+
+	if len(from) > 5 {
+		if v, ok, err := remapperUserInt(from[5]); err != nil {
+			return nil, errors.New("Could not convert 'Level'. " + err.Error())
+		} else if ok {
+			to.Level = int(v)
+			mapped = true
+		}
+	}
+
+	if !mapped {
+		return nil, nil
+	}
+
+	return to, nil
+}
+
+// UserToMap maps User into map same way as remapper.Mapper.Map with map[string]string{}. Returns nil if nothing was mapped.
+func UserToMap(from *User) (map[string]string, error) {
+	to := make(map[string]string, 7)
+	mapped := false
+
+	to["id"] = strconv.FormatUint(uint64(from.ID), 10)
+	mapped = true
+
+	if v := strings.TrimSpace(from.Name); len(v) > 0 {
+		to["name"] = v
+		mapped = true
+	}
+
+	to["age"] = strconv.FormatInt(int64(from.Age), 10)
+	mapped = true
+
+	to["score"] = strconv.FormatFloat(float64(from.Score), 'f', 4, 32)
+	mapped = true
+
+	to["active"] = strconv.FormatBool(from.Active)
+	mapped = true
+
+	to["5"] = strconv.FormatInt(int64(from.Level), 10)
+	mapped = true
+
+	if v := strings.TrimSpace(from.Email); len(v) > 0 {
+		to["Email"] = v
+		mapped = true
+	}
+
+	if !mapped {
+		return nil, nil
+	}
+
+	return to, nil
+}
+
+// UserFromMap maps map into User same way as remapper.Mapper.Map with map[string]string{}. Returns nil if nothing was mapped.
+func UserFromMap(from map[string]string) (*User, error) {
+	to := &User{}
+	mapped := false
+
+	if v, ok, err := remapperUserUint(remapperUserLookup(from, "id")); err != nil {
+		return nil, errors.New("Could not convert 'ID'. " + err.Error())
+	} else if ok {
+		to.ID = uint64(v)
+		mapped = true
+	}
+
+	if v := strings.TrimSpace(remapperUserLookup(from, "name")); len(v) > 0 {
+		to.Name = v
+		mapped = true
+	}
+
+	if v, ok, err := remapperUserInt(remapperUserLookup(from, "age")); err != nil {
+		return nil, errors.New("Could not convert 'Age'. " + err.Error())
+	} else if ok {
+		to.Age = int8(v)
+		mapped = true
+	}
+
+	if v, ok, err := remapperUserFloat(remapperUserLookup(from, "score")); err != nil {
+		return nil, errors.New("Could not convert 'Score'. " + err.Error())
+	} else if ok {
+		to.Score = float32(v)
+		mapped = true
+	}
+
+	if v, ok, err := remapperUserBool(remapperUserLookup(from, "active")); err != nil {
+		return nil, errors.New("Could not convert 'Active'. " + err.Error())
+	} else if ok {
+		to.Active = v
+		mapped = true
+	}
+
+	if v, ok, err := remapperUserInt(remapperUserLookup(from, "5")); err != nil {
+		return nil, errors.New("Could not convert 'Level'. " + err.Error())
+	} else if ok {
+		to.Level = int(v)
+		mapped = true
+	}
+
+	if v := strings.TrimSpace(remapperUserLookup(from, "Email")); len(v) > 0 {
+		to.Email = v
+		mapped = true
+	}
+
+	if !mapped {
+		return nil, nil
+	}
+
+	return to, nil
+}
+
+// UserRowToSlice maps UserRow into slice same way as remapper.Mapper.Map with []string{}. Returns nil if nothing was mapped.
+func UserRowToSlice(from *UserRow) ([]string, error) {
+	to := make([]string, 4)
+	mapped := false
+
+	to[0] = strconv.FormatUint(uint64(from.ID), 10)
+	mapped = true
+
+	if v := strings.TrimSpace(from.Name); len(v) > 0 {
+		to[1] = v
+		mapped = true
+	}
+
+	if !mapped {
+		return nil, nil
+	}
+
+	return to, nil
+}
+
+// UserRowFromSlice maps slice into UserRow same way as remapper.Mapper.Map with []string{}. Returns nil if nothing was mapped.
+func UserRowFromSlice(from []string) (*UserRow, error) {
+	to := &UserRow{}
+	mapped := false
+
+	if len(from) > 0 {
+		if v, ok, err := remapperUserUint(from[0]); err != nil {
+			return nil, errors.New("Could not convert 'ID'. " + err.Error())
+		} else if ok {
+			to.ID = uint64(v)
+			mapped = true
+		}
+	}
+
+	if len(from) > 1 {
+		if v := strings.TrimSpace(from[1]); len(v) > 0 {
+			to.Name = v
+			mapped = true
+		}
+	}
+
+	if !mapped {
+		return nil, nil
+	}
+
+	return to, nil
+}
+
+// remapperUserBool converts a string to bool same way as remapper.Convert. Returns false if string is empty.
+func remapperUserBool(s string) (bool, bool, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return false, false, nil
+	}
+
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, false, err
+	}
+
+	return v, true, nil
+}
+
+// remapperUserFloat converts a string to float64 same way as remapper.Convert. Returns false if string is empty.
+func remapperUserFloat(s string) (float64, bool, error) {
+	s = strings.Replace(strings.TrimSpace(s), ",", ".", -1)
+	if len(s) == 0 {
+		return 0, false, nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return v, true, nil
+}
+
+// remapperUserInt converts a string to int64 same way as remapper.Convert. Returns false if string is empty.
+func remapperUserInt(s string) (int64, bool, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return 0, false, nil
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		//value can be a float
+		f, err2 := strconv.ParseFloat(s, 64)
+		if err2 != nil {
+			return 0, false, err
+		}
+
+		v = int64(f)
+	}
+
+	return v, true, nil
+}
+
+// remapperUserLookup returns a value of key from map. Keys are matched in lower case if there is no exact key.
+func remapperUserLookup(m map[string]string, key string) string {
+	if v, ok := m[key]; ok {
+		return v
+	}
+
+	key = strings.ToLower(key)
+	for k, v := range m {
+		if strings.ToLower(k) == key {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// remapperUserUint converts a string to uint64 same way as remapper.Convert. Returns false if string is empty.
+func remapperUserUint(s string) (uint64, bool, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return 0, false, nil
+	}
+
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		//value can be a float
+		f, err2 := strconv.ParseFloat(s, 64)
+		if err2 != nil {
+			return 0, false, err
+		}
+
+		v = uint64(f)
+	}
+
+	return v, true, nil
+}
@@ -0,0 +1,70 @@
+package example
+
+import (
+    "testing"
+
+    "github.com/plandem/remapper"
+    "github.com/plandem/remapper/remappertest"
+    "github.com/stretchr/testify/require"
+)
+
+var userNames = []string{"id", "name", "age", "score", "active", "level"}
+
+var userStructs = []*User{
+    {},
+    {ID: 1, Name: " John ", Age: -20, Score: 1.23456, Active: true, Level: 3, Email: "john@example.com"},
+    {ID: 18446744073709551615, Age: 127, Score: -0.5},
+}
+
+var userRows = [][]string{
+    nil,
+    {},
+    {"", " ", ""},
+    {"1", "John", "20", "1,5", "true", "3"},
+    {" 2 ", "", "3.7", "", "", "-4"},
+    {"x"},
+    {"1", "John", "20", "1.5", "yes"},
+    {"1", "John", "20", "1.5"},
+}
+
+var userMaps = []map[string]string{
+    nil,
+    {},
+    {"id": "1", "Name": "John", "AGE": "20", "score": "1,5", "active": "true", "5": "3", "email": "john@example.com"},
+    {"Email": " ", "unknown": "1"},
+    {"id": "-1"},
+    {"score": "abc"},
+}
+
+func TestUserSlice(t *testing.T) {
+    mapper, err := remapper.New(&User{}, remapper.Slice([]string{}, userNames))
+    require.Nil(t, err)
+
+    remappertest.Compare(t, mapper, UserToSlice, userStructs...)
+    remappertest.Compare(t, mapper, UserFromSlice, userRows...)
+}
+
+func TestUserMap(t *testing.T) {
+    mapper, err := remapper.New(&User{}, map[string]string{})
+    require.Nil(t, err)
+
+    remappertest.Compare(t, mapper, UserToMap, userStructs...)
+    remappertest.Compare(t, mapper, UserFromMap, userMaps...)
+}
+
+func TestUserRow(t *testing.T) {
+    mapper, err := remapper.New(&UserRow{}, []string{})
+    require.Nil(t, err)
+
+    remappertest.Compare(t, mapper, UserRowToSlice, []*UserRow{
+        {},
+        {ID: 1, Name: "John", Note: "not mapped"},
+    }...)
+
+    remappertest.Compare(t, mapper, UserRowFromSlice, [][]string{
+        nil,
+        {"1"},
+        {"1", "John", "", "not mapped"},
+        {"x", "John"},
+    }...)
+}
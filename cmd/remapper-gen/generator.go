@@ -0,0 +1,663 @@
+package main
+
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "go/ast"
+    "go/format"
+    "go/parser"
+    "go/token"
+    "go/types"
+    "path/filepath"
+    "reflect"
+    "sort"
+    "strconv"
+    "strings"
+    "unicode"
+
+    "github.com/plandem/remapper/internal/tags"
+)
+
+const (
+    sliceTarget = "slice"
+    mapTarget   = "map"
+
+    // directive is a prefix of comment that selects targets of struct
+    directive = "//remapper:gen"
+)
+
+// target is a type to generate mapping functions for
+type target struct {
+    kind  string   //slice or map
+    names []string //names of named slice
+}
+
+// structField is a field of parsed struct
+type structField struct {
+    name     string
+    typeName string
+    tag      reflect.StructTag
+}
+
+// parsedStruct is a struct with targets to generate functions for
+type parsedStruct struct {
+    name    string
+    fields  []structField
+    targets []target
+}
+
+// linkedField is a field of struct that is linked to position of slice or key of map
+type linkedField struct {
+    structField
+    kind  string //kind of value: string, int, uint, float or bool
+    bits  int    //bit size of float
+    index int    //position at slice
+    key   string //key of map
+}
+
+// valueKind is a kind of value with bit size
+type valueKind struct {
+    kind string
+    bits int
+}
+
+// valueKinds holds types of fields that can be converted same way as remapper.Convert does
+var valueKinds = map[string]valueKind{
+    "string":  {"string", 0},
+    "bool":    {"bool", 0},
+    "int":     {"int", 0},
+    "int8":    {"int", 0},
+    "int16":   {"int", 0},
+    "int32":   {"int", 0},
+    "int64":   {"int", 0},
+    "rune":    {"int", 0},
+    "uint":    {"uint", 0},
+    "uint8":   {"uint", 0},
+    "uint16":  {"uint", 0},
+    "uint32":  {"uint", 0},
+    "uint64":  {"uint", 0},
+    "byte":    {"uint", 0},
+    "float32": {"float", 32},
+    "float64": {"float", 64},
+}
+
+// generateFile parses structs of file with path and returns generated code for structs with directives and for struct typeName with targets.
+// Tags tagName are read for profile, see Config.Profile of remapper.
+func generateFile(path string, tagName string, profile string, typeName string, targets []target) ([]byte, error) {
+    fset := token.NewFileSet()
+    file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+    if err != nil {
+        return nil, err
+    }
+
+    structs, err := parseStructs(fset, file)
+    if err != nil {
+        return nil, err
+    }
+
+    if len(typeName) > 0 {
+        found := false
+        for _, s := range structs {
+            if s.name == typeName {
+                s.targets = append(s.targets, targets...)
+                found = true
+            }
+        }
+
+        if !found {
+            return nil, errors.New(fmt.Sprintf("There is no struct '%s' at %s", typeName, path))
+        }
+    }
+
+    g := &generator{
+        tagName: tagName,
+        profile: profile,
+        prefix:  "remapper" + camelName(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))),
+        imports: map[string]bool{},
+        helpers: map[string]bool{},
+    }
+
+    for _, s := range structs {
+        if err := g.generateStruct(s); err != nil {
+            return nil, err
+        }
+    }
+
+    return g.source(file.Name.Name, filepath.Base(path))
+}
+
+// parseStructs returns all structs of file with targets from directives
+func parseStructs(fset *token.FileSet, file *ast.File) ([]*parsedStruct, error) {
+    var structs []*parsedStruct
+
+    for _, decl := range file.Decls {
+        genDecl, ok := decl.(*ast.GenDecl)
+        if !ok || genDecl.Tok != token.TYPE {
+            continue
+        }
+
+        for _, spec := range genDecl.Specs {
+            typeSpec := spec.(*ast.TypeSpec)
+            structType, ok := typeSpec.Type.(*ast.StructType)
+            if !ok {
+                continue
+            }
+
+            s := &parsedStruct{name: typeSpec.Name.Name}
+
+            //doc of single type is attached to declaration
+            doc := typeSpec.Doc
+            if doc == nil && len(genDecl.Specs) == 1 {
+                doc = genDecl.Doc
+            }
+
+            if doc != nil {
+                for _, comment := range doc.List {
+                    if !strings.HasPrefix(comment.Text, directive) {
+                        continue
+                    }
+
+                    targets, err := parseDirective(strings.TrimPrefix(comment.Text, directive))
+                    if err != nil {
+                        return nil, errors.New(fmt.Sprintf("%s: %s", fset.Position(comment.Pos()), err.Error()))
+                    }
+
+                    s.targets = append(s.targets, targets...)
+                }
+            }
+
+            for _, field := range structType.Fields.List {
+                var tag reflect.StructTag
+                if field.Tag != nil {
+                    value, err := strconv.Unquote(field.Tag.Value)
+                    if err != nil {
+                        return nil, errors.New(fmt.Sprintf("%s: %s", fset.Position(field.Tag.Pos()), err.Error()))
+                    }
+
+                    tag = reflect.StructTag(value)
+                }
+
+                typeName := types.ExprString(field.Type)
+
+                //embedded field has name of type
+                if len(field.Names) == 0 {
+                    name := typeName[strings.LastIndex(typeName, ".")+1:]
+                    s.fields = append(s.fields, structField{name: strings.TrimPrefix(name, "*"), typeName: typeName, tag: tag})
+                    continue
+                }
+
+                for _, name := range field.Names {
+                    s.fields = append(s.fields, structField{name: name.Name, typeName: typeName, tag: tag})
+                }
+            }
+
+            structs = append(structs, s)
+        }
+    }
+
+    return structs, nil
+}
+
+// parseDirective returns targets of directive, e.g.: 'slice=first_name,last_name map'
+func parseDirective(text string) ([]target, error) {
+    var targets []target
+
+    for _, option := range strings.Fields(text) {
+        switch {
+        case option == sliceTarget:
+            targets = append(targets, target{kind: sliceTarget})
+        case strings.HasPrefix(option, sliceTarget+"="):
+            targets = append(targets, target{kind: sliceTarget, names: strings.Split(strings.TrimPrefix(option, sliceTarget+"="), ",")})
+        case option == mapTarget:
+            targets = append(targets, target{kind: mapTarget})
+        default:
+            return nil, errors.New(fmt.Sprintf("Unknown target '%s' of directive. Supports only 'slice', 'slice=names' and 'map'.", option))
+        }
+    }
+
+    if len(targets) == 0 {
+        return nil, errors.New("You must provide at least one target of directive.")
+    }
+
+    return targets, nil
+}
+
+// linkField returns a field linked to target or error if type of field is not supported
+func linkField(f structField) (linkedField, error) {
+    if !ast.IsExported(f.name) {
+        return linkedField{}, errors.New(fmt.Sprintf("Field '%s' is not exported.", f.name))
+    }
+
+    kind, ok := valueKinds[f.typeName]
+    if !ok {
+        return linkedField{}, errors.New(fmt.Sprintf("Field '%s' has unsupported type '%s'.", f.name, f.typeName))
+    }
+
+    return linkedField{structField: f, kind: kind.kind, bits: kind.bits}, nil
+}
+
+// tagMapping returns a name of field from tag for profile and true if field is omitted or false if field is not linked via tag
+func tagMapping(f structField, tagName string, profile string) (string, bool, bool, error) {
+    tag := f.tag.Get(tagName)
+    fromTag := tags.Profile(tag, profile)
+    if len(profile) == 0 && len(tag) > 0 && len(fromTag) == 0 {
+        return "", false, false, errors.New(fmt.Sprintf("Field '%s' has no section without name of profile. You must provide -profile.", f.name))
+    }
+
+    if len(fromTag) == 0 {
+        return "", false, false, nil
+    }
+
+    name, options := tags.Parse(fromTag)
+    if options.Contains("omit") || options.Contains("-") {
+        return name, true, true, nil
+    }
+
+    for _, option := range []string{"inline", "rest"} {
+        if options.Contains(option) {
+            return "", false, false, errors.New(fmt.Sprintf("Option '%s' of field '%s' is not supported.", option, f.name))
+        }
+    }
+
+    if strings.Contains(name, "+") {
+        return "", false, false, errors.New(fmt.Sprintf("Combined field '%s' is not supported.", f.name))
+    }
+
+    return name, false, true, nil
+}
+
+// sliceFields returns fields of struct s linked to slice t and width of slice
+func sliceFields(s *parsedStruct, t target, tagName string, profile string) ([]linkedField, int, error) {
+    positions := make(map[string]int, len(t.names))
+    for i, name := range t.names {
+        if _, ok := positions[strings.ToLower(name)]; ok {
+            return nil, 0, errors.New(fmt.Sprintf("Name '%s' of slice collides with other name.", name))
+        }
+
+        positions[strings.ToLower(name)] = i
+    }
+
+    width := len(t.names)
+    linked := make(map[int]string)
+    var fields []linkedField
+
+    for _, f := range s.fields {
+        name, omit, ok, err := tagMapping(f, tagName, profile)
+        if err != nil {
+            return nil, 0, err
+        }
+
+        if !ok {
+            continue
+        }
+
+        index, err := strconv.Atoi(name)
+        if err == nil {
+            if index < 0 || (len(t.names) > 0 && index >= len(t.names)) {
+                return nil, 0, errors.New(fmt.Sprintf("Index %d of field '%s' is out of range of slice.", index, f.name))
+            }
+        } else if index, ok = positions[strings.ToLower(name)]; !ok {
+            return nil, 0, errors.New(fmt.Sprintf("There is no field with name '%s' at slice.", name))
+        }
+
+        //omitted field is not mapped, but position of it is still a part of slice
+        if index >= width {
+            width = index + 1
+        }
+
+        if omit {
+            continue
+        }
+
+        if other, ok := linked[index]; ok {
+            return nil, 0, errors.New(fmt.Sprintf("Fields '%s' and '%s' are linked to same position %d of slice.", other, f.name, index))
+        }
+
+        field, err := linkField(f)
+        if err != nil {
+            return nil, 0, err
+        }
+
+        field.index = index
+        linked[index] = f.name
+        fields = append(fields, field)
+    }
+
+    if width == 0 {
+        return nil, 0, errors.New(fmt.Sprintf("Can't get length of slice for '%s'. There are no linked fields.", s.name))
+    }
+
+    return fields, width, nil
+}
+
+// mapFields returns fields of struct s linked to map. Fields without tags are linked via own names.
+func mapFields(s *parsedStruct, tagName string, profile string) ([]linkedField, error) {
+    linked := make(map[string]string)
+    var fields []linkedField
+
+    for _, f := range s.fields {
+        key := f.name
+
+        if _, hasTag := f.tag.Lookup(tagName); hasTag {
+            name, omit, ok, err := tagMapping(f, tagName, profile)
+            if err != nil {
+                return nil, err
+            }
+
+            if !ok || omit {
+                continue
+            }
+
+            key = name
+        } else if !ast.IsExported(f.name) {
+            continue
+        }
+
+        if other, ok := linked[strings.ToLower(key)]; ok {
+            return nil, errors.New(fmt.Sprintf("Fields '%s' and '%s' are linked to same key '%s' of map.", other, f.name, key))
+        }
+
+        field, err := linkField(f)
+        if err != nil {
+            return nil, err
+        }
+
+        field.key = key
+        linked[strings.ToLower(key)] = f.name
+        fields = append(fields, field)
+    }
+
+    return fields, nil
+}
+
+// camelName returns a name with upper case letter at start of each part, e.g.: 'user_model' -> 'UserModel'
+func camelName(name string) (string) {
+    var b strings.Builder
+
+    for _, part := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+        b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+    }
+
+    return b.String()
+}
+
+// generator writes functions of structs and helpers that are used by them
+type generator struct {
+    bytes.Buffer
+    tagName string
+    profile string
+    prefix  string          //prefix of helpers, so few generated files can be in same package
+    imports map[string]bool //packages that are used by generated code
+    helpers map[string]bool //helpers that are used by generated code
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+    fmt.Fprintf(&g.Buffer, format, args...)
+}
+
+// generateStruct writes functions for each target of struct s
+func (g *generator) generateStruct(s *parsedStruct) (error) {
+    if len(g.profile) > 0 {
+        hasProfile := false
+        for _, f := range s.fields {
+            hasProfile = hasProfile || tags.HasProfile(f.tag.Get(g.tagName), g.profile)
+        }
+
+        if !hasProfile {
+            return errors.New(fmt.Sprintf("%s: There is no section for profile '%s' at tags '%s'.", s.name, g.profile, g.tagName))
+        }
+    }
+
+    kinds := map[string]bool{}
+
+    for _, t := range s.targets {
+        if kinds[t.kind] {
+            return errors.New(fmt.Sprintf("Struct '%s' can have only one target of '%s'.", s.name, t.kind))
+        }
+
+        kinds[t.kind] = true
+
+        switch t.kind {
+        case sliceTarget:
+            fields, width, err := sliceFields(s, t, g.tagName, g.profile)
+            if err != nil {
+                return errors.New(fmt.Sprintf("%s: %s", s.name, err.Error()))
+            }
+
+            g.generateSlice(s, t, fields, width)
+        case mapTarget:
+            fields, err := mapFields(s, g.tagName, g.profile)
+            if err != nil {
+                return errors.New(fmt.Sprintf("%s: %s", s.name, err.Error()))
+            }
+
+            g.generateMap(s, fields)
+        }
+    }
+
+    return nil
+}
+
+// generateSlice writes functions to map struct s to/from slice
+func (g *generator) generateSlice(s *parsedStruct, t target, fields []linkedField, width int) {
+    mapper := "[]string{}"
+    if len(t.names) > 0 {
+        mapper = fmt.Sprintf("remapper.Slice([]string{}, %#v)", t.names)
+    }
+
+    g.printf("// %sToSlice maps %s into slice same way as remapper.Mapper.Map with %s. Returns nil if nothing was mapped.\n", s.name, s.name, mapper)
+    g.printf("func %sToSlice(from *%s) ([]string, error) {\n", s.name, s.name)
+    g.printf("to := make([]string, %d)\nmapped := false\n\n", width)
+    for _, f := range fields {
+        g.writeTo(fmt.Sprintf("to[%d]", f.index), f)
+    }
+    g.printf("if !mapped {\nreturn nil, nil\n}\n\nreturn to, nil\n}\n\n")
+
+    g.printf("// %sFromSlice maps slice into %s same way as remapper.Mapper.Map with %s. Returns nil if nothing was mapped.\n", s.name, s.name, mapper)
+    g.printf("func %sFromSlice(from []string) (*%s, error) {\n", s.name, s.name)
+    g.printf("to := &%s{}\nmapped := false\n\n", s.name)
+    for _, f := range fields {
+        g.printf("if len(from) > %d {\n", f.index)
+        g.writeFrom(fmt.Sprintf("from[%d]", f.index), f)
+        g.printf("}\n\n")
+    }
+    g.printf("if !mapped {\nreturn nil, nil\n}\n\nreturn to, nil\n}\n\n")
+}
+
+// generateMap writes functions to map struct s to/from map
+func (g *generator) generateMap(s *parsedStruct, fields []linkedField) {
+    g.printf("// %sToMap maps %s into map same way as remapper.Mapper.Map with map[string]string{}. Returns nil if nothing was mapped.\n", s.name, s.name)
+    g.printf("func %sToMap(from *%s) (map[string]string, error) {\n", s.name, s.name)
+    g.printf("to := make(map[string]string, %d)\nmapped := false\n\n", len(fields))
+    for _, f := range fields {
+        g.writeTo(fmt.Sprintf("to[%q]", f.key), f)
+    }
+    g.printf("if !mapped {\nreturn nil, nil\n}\n\nreturn to, nil\n}\n\n")
+
+    g.printf("// %sFromMap maps map into %s same way as remapper.Mapper.Map with map[string]string{}. Returns nil if nothing was mapped.\n", s.name, s.name)
+    g.printf("func %sFromMap(from map[string]string) (*%s, error) {\n", s.name, s.name)
+    g.printf("to := &%s{}\nmapped := false\n\n", s.name)
+    for _, f := range fields {
+        g.helpers["Lookup"] = true
+        g.writeFrom(fmt.Sprintf("%sLookup(from, %q)", g.prefix, f.key), f)
+        g.printf("\n")
+    }
+    g.printf("if !mapped {\nreturn nil, nil\n}\n\nreturn to, nil\n}\n\n")
+}
+
+// writeTo writes a conversion of field f into string that is set to 'to'
+func (g *generator) writeTo(to string, f linkedField) {
+    from := "from." + f.name
+
+    switch f.kind {
+    case "string":
+        g.imports["strings"] = true
+        g.printf("if v := strings.TrimSpace(%s); len(v) > 0 {\n%s = v\nmapped = true\n}\n\n", from, to)
+        return
+    case "int":
+        g.printf("%s = strconv.FormatInt(int64(%s), 10)\n", to, from)
+    case "uint":
+        g.printf("%s = strconv.FormatUint(uint64(%s), 10)\n", to, from)
+    case "float":
+        g.printf("%s = strconv.FormatFloat(float64(%s), 'f', 4, %d)\n", to, from, f.bits)
+    case "bool":
+        g.printf("%s = strconv.FormatBool(%s)\n", to, from)
+    }
+
+    g.imports["strconv"] = true
+    g.printf("mapped = true\n\n")
+}
+
+// writeFrom writes a conversion of string 'from' into field f
+func (g *generator) writeFrom(from string, f linkedField) {
+    g.imports["strings"] = true
+
+    if f.kind == "string" {
+        g.printf("if v := strings.TrimSpace(%s); len(v) > 0 {\nto.%s = v\nmapped = true\n}\n", from, f.name)
+        return
+    }
+
+    helper := strings.ToUpper(f.kind[:1]) + f.kind[1:]
+    g.helpers[helper] = true
+    g.imports["errors"] = true
+    g.imports["strconv"] = true
+
+    value := "v"
+    if f.kind != "bool" {
+        value = fmt.Sprintf("%s(v)", f.typeName)
+    }
+
+    g.printf("if v, ok, err := %s%s(%s); err != nil {\n", g.prefix, helper, from)
+    g.printf("return nil, errors.New(\"Could not convert '%s'. \" + err.Error())\n", f.name)
+    g.printf("} else if ok {\nto.%s = %s\nmapped = true\n}\n", f.name, value)
+}
+
+// helpers holds code of helpers. Helpers convert strings same way as remapper.Convert.
+var helpers = map[string]string{
+    "Int": `// %[1]sInt converts a string to int64 same way as remapper.Convert. Returns false if string is empty.
+func %[1]sInt(s string) (int64, bool, error) {
+    s = strings.TrimSpace(s)
+    if len(s) == 0 {
+        return 0, false, nil
+    }
+
+    v, err := strconv.ParseInt(s, 10, 64)
+    if err != nil {
+        //value can be a float
+        f, err2 := strconv.ParseFloat(s, 64)
+        if err2 != nil {
+            return 0, false, err
+        }
+
+        v = int64(f)
+    }
+
+    return v, true, nil
+}
+`,
+    "Uint": `// %[1]sUint converts a string to uint64 same way as remapper.Convert. Returns false if string is empty.
+func %[1]sUint(s string) (uint64, bool, error) {
+    s = strings.TrimSpace(s)
+    if len(s) == 0 {
+        return 0, false, nil
+    }
+
+    v, err := strconv.ParseUint(s, 10, 64)
+    if err != nil {
+        //value can be a float
+        f, err2 := strconv.ParseFloat(s, 64)
+        if err2 != nil {
+            return 0, false, err
+        }
+
+        v = uint64(f)
+    }
+
+    return v, true, nil
+}
+`,
+    "Float": `// %[1]sFloat converts a string to float64 same way as remapper.Convert. Returns false if string is empty.
+func %[1]sFloat(s string) (float64, bool, error) {
+    s = strings.Replace(strings.TrimSpace(s), ",", ".", -1)
+    if len(s) == 0 {
+        return 0, false, nil
+    }
+
+    v, err := strconv.ParseFloat(s, 64)
+    if err != nil {
+        return 0, false, err
+    }
+
+    return v, true, nil
+}
+`,
+    "Bool": `// %[1]sBool converts a string to bool same way as remapper.Convert. Returns false if string is empty.
+func %[1]sBool(s string) (bool, bool, error) {
+    s = strings.TrimSpace(s)
+    if len(s) == 0 {
+        return false, false, nil
+    }
+
+    v, err := strconv.ParseBool(s)
+    if err != nil {
+        return false, false, err
+    }
+
+    return v, true, nil
+}
+`,
+    "Lookup": `// %[1]sLookup returns a value of key from map. Keys are matched in lower case if there is no exact key.
+func %[1]sLookup(m map[string]string, key string) string {
+    if v, ok := m[key]; ok {
+        return v
+    }
+
+    key = strings.ToLower(key)
+    for k, v := range m {
+        if strings.ToLower(k) == key {
+            return v
+        }
+    }
+
+    return ""
+}
+`,
+}
+
+// source returns formatted code of package pkg with generated functions and helpers
+func (g *generator) source(pkg string, fileName string) ([]byte, error) {
+    var b bytes.Buffer
+
+    fmt.Fprintf(&b, "// Code generated by remapper-gen from %s. DO NOT EDIT.\n\npackage %s\n\n", fileName, pkg)
+
+    if len(g.imports) > 0 {
+        var imports []string
+        for name := range g.imports {
+            imports = append(imports, strconv.Quote(name))
+        }
+
+        sort.Strings(imports)
+        fmt.Fprintf(&b, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+    }
+
+    b.Write(g.Bytes())
+
+    var names []string
+    for name := range g.helpers {
+        names = append(names, name)
+    }
+
+    sort.Strings(names)
+    for _, name := range names {
+        fmt.Fprintf(&b, helpers[name], g.prefix)
+        b.WriteString("\n")
+    }
+
+    code, err := format.Source(b.Bytes())
+    if err != nil {
+        return nil, errors.New(fmt.Sprintf("Could not format generated code. %s", err.Error()))
+    }
+
+    return code, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+    "go/parser"
+    "go/token"
+    "os"
+    "path/filepath"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestGenerateExample(t *testing.T) {
+    //generated code of example must be up to date
+    code, err := generateFile(filepath.Join("example", "user.go"), "remapper", "", "", nil)
+    require.Nil(t, err)
+
+    expected, err := os.ReadFile(filepath.Join("example", "user_remapper.go"))
+    require.Nil(t, err)
+    require.Equal(t, string(expected), string(code))
+
+    //targets via command line
+    dir := t.TempDir()
+    input := filepath.Join(dir, "user.go")
+    require.Nil(t, os.WriteFile(input, []byte("package users\n\ntype User struct {\n    Name string `csv:\"0\"`\n    Age int `csv:\"2\"`\n}\n"), 0644))
+    require.Nil(t, run([]string{"-tag", "csv", "-type", "User", "-indexed", input}))
+
+    code, err = os.ReadFile(filepath.Join(dir, "user_remapper.go"))
+    require.Nil(t, err)
+    assert.Contains(t, string(code), "to := make([]string, 3)")
+    assert.Contains(t, string(code), "func UserFromSlice(from []string) (*User, error)")
+
+    _, err = parser.ParseFile(token.NewFileSet(), "", code, 0)
+    require.Nil(t, err)
+
+    require.NotNil(t, run([]string{"-indexed", input}))
+    require.NotNil(t, run([]string{"-type", "Unknown", "-indexed", input}))
+}
+
+func TestGenerateErrors(t *testing.T) {
+    dir := t.TempDir()
+
+    for name, code := range map[string]string{
+        "directive": "//remapper:gen table\ntype T struct { A int `remapper:\"0\"` }",
+        "type":      "//remapper:gen slice\ntype T struct { A []int `remapper:\"0\"` }",
+        "unexported":"//remapper:gen slice\ntype T struct { a int `remapper:\"0\"` }",
+        "name":      "//remapper:gen slice=a,b\ntype T struct { A int `remapper:\"c\"` }",
+        "range":     "//remapper:gen slice=a,b\ntype T struct { A int `remapper:\"2\"` }",
+        "same":      "//remapper:gen slice\ntype T struct { A int `remapper:\"0\"`\nB int `remapper:\"0\"` }",
+        "empty":     "//remapper:gen slice\ntype T struct { A int }",
+        "combined":  "//remapper:gen slice=a,b\ntype T struct { A string `remapper:\"a+b\"` }",
+        "rest":      "//remapper:gen map\ntype T struct { A map[string]string `remapper:\",rest\"` }",
+        "key":       "//remapper:gen map\ntype T struct { A int `remapper:\"a\"`\nB int `remapper:\"A\"` }",
+        "derived":   "//remapper:gen map\ntype T struct { A int\nB []int }",
+        "targets":   "//remapper:gen slice map slice\ntype T struct { A int `remapper:\"0\"` }",
+    } {
+        input := filepath.Join(dir, name+".go")
+        require.Nil(t, os.WriteFile(input, []byte("package p\n\n"+code+"\n"), 0644))
+
+        _, err := generateFile(input, "remapper", "", "", nil)
+        assert.NotNil(t, err, name)
+    }
+
+    //tags of profile
+    input := filepath.Join(dir, "profile.go")
+    require.Nil(t, os.WriteFile(input, []byte("package p\n\n//remapper:gen slice\ntype T struct {\nA int `remapper:\"csv=0;db=a\"`\nB int `remapper:\"csv=2\"`\n}\n"), 0644))
+
+    code, err := generateFile(input, "remapper", "csv", "", nil)
+    require.Nil(t, err)
+    assert.Contains(t, string(code), "to := make([]string, 3)")
+
+    _, err = generateFile(input, "remapper", "db", "", nil)
+    assert.NotNil(t, err)
+
+    _, err = generateFile(input, "remapper", "cvs", "", nil)
+    assert.NotNil(t, err)
+
+    _, err = generateFile(input, "remapper", "", "", nil)
+    assert.NotNil(t, err)
+
+    //omitted fields and fields without tags are skipped, but omitted positions are part of slice
+    input = filepath.Join(dir, "omit.go")
+    require.Nil(t, os.WriteFile(input, []byte("package p\n\n//remapper:gen slice\ntype T struct {\nA int `remapper:\"0\"`\nB []int `remapper:\"1,omit\"`\nC []int\n}\n"), 0644))
+
+    code, err = generateFile(input, "remapper", "", "", nil)
+    require.Nil(t, err)
+    assert.Contains(t, string(code), "to := make([]string, 2)")
+    assert.NotContains(t, string(code), "from.B")
+}
@@ -0,0 +1,87 @@
+// Command remapper-gen generates plain Go functions that map structs to/from slices and maps same way as remapper.Mapper.Map, but without reflection.
+//
+// Struct is selected via directive comment or via command line:
+//
+//    //remapper:gen slice=first_name,last_name,age map
+//    type User struct {
+//        FirstName string `remapper:"first_name"`
+//        LastName  string `remapper:"last_name"`
+//        Age       int    `remapper:"age"`
+//    }
+//
+// - 'slice=names' generates functions for named slice, i.e. remapper.Slice([]string{}, names)
+// - 'slice' generates functions for indexed slice, i.e. []string{}
+// - 'map' generates functions for map with names derived from struct, i.e. map[string]string{}
+//
+// For each target two functions are generated, e.g. UserToSlice/UserFromSlice and UserToMap/UserFromMap.
+// Generated functions use default Config, i.e. names are matched in lower case and values are converted via remapper.Convert.
+//
+// Usage:
+//
+//    remapper-gen [-tag remapper] [-profile csv] [-type User -slice first_name,last_name,age | -indexed | -map] [-output user_remapper.go] user.go
+package main
+
+import (
+    "errors"
+    "flag"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+func main() {
+    if err := run(os.Args[1:]); err != nil {
+        fmt.Fprintln(os.Stderr, "remapper-gen:", err)
+        os.Exit(1)
+    }
+}
+
+// run parses arguments of command line and writes generated code
+func run(args []string) (error) {
+    flags := flag.NewFlagSet("remapper-gen", flag.ContinueOnError)
+    tagName := flags.String("tag", "remapper", "name of the tag to use on struct fields")
+    profile := flags.String("profile", "", "name of profile of tags to use, e.g. 'csv' for `remapper:\"csv=3;db=user_id\"`")
+    typeName := flags.String("type", "", "name of struct to generate functions for in addition to directives")
+    sliceNames := flags.String("slice", "", "comma separated names of named slice for struct provided via -type")
+    indexed := flags.Bool("indexed", false, "generate functions of indexed slice for struct provided via -type")
+    withMap := flags.Bool("map", false, "generate functions of map for struct provided via -type")
+    output := flags.String("output", "", "name of output file. Default: <file>_remapper.go")
+
+    if err := flags.Parse(args); err != nil {
+        return err
+    }
+
+    if flags.NArg() != 1 {
+        return errors.New("You must provide exactly one file with structs.")
+    }
+
+    var targets []target
+    if len(*sliceNames) > 0 {
+        targets = append(targets, target{kind: sliceTarget, names: strings.Split(*sliceNames, ",")})
+    }
+
+    if *indexed {
+        targets = append(targets, target{kind: sliceTarget})
+    }
+
+    if *withMap {
+        targets = append(targets, target{kind: mapTarget})
+    }
+
+    if len(targets) > 0 && len(*typeName) == 0 {
+        return errors.New("You must provide -type for -slice, -indexed or -map.")
+    }
+
+    input := flags.Arg(0)
+    code, err := generateFile(input, *tagName, *profile, *typeName, targets)
+    if err != nil {
+        return err
+    }
+
+    if len(*output) == 0 {
+        *output = strings.TrimSuffix(input, filepath.Ext(input)) + "_remapper.go"
+    }
+
+    return os.WriteFile(*output, code, 0644)
+}
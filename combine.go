@@ -0,0 +1,265 @@
+package remapper
+
+import (
+    "errors"
+    "fmt"
+    "reflect"
+    "strconv"
+    "strings"
+    "sync"
+
+    "github.com/plandem/remapper/internal/tags"
+)
+
+// combinedSeparator is a separator of names or indexes for a field that is combined from few linked fields, e.g.: 'year+month+day'
+const combinedSeparator = "+"
+
+// Combiner holds functions to combine values of few linked fields into one value and to split it back
+type Combiner struct {
+    // Combine returns a value combined from values of linked fields. Return nil to skip the value.
+    Combine func(values []interface{}) (interface{}, error)
+
+    // Split returns values for linked fields from a combined value
+    Split func(value interface{}) ([]interface{}, error)
+}
+
+// combinedFields holds information to map between one field and few linked fields
+type combinedFields struct {
+    // Names of linked fields in order of combining
+    names []string
+
+    // Combiner to combine/split values of linked fields
+    combiner Combiner
+
+    // Option that was used to get combiner, e.g. 'join=-' or 'combine=date', to describe a configuration of mapper
+    option string
+}
+
+var (
+    combinersMu sync.RWMutex
+    combiners   = map[string]Combiner{}
+)
+
+// RegisterCombiner registers a combiner with name, so it can be used via 'combine=name' option, e.g.: `remapper:"year+month+day,combine=date"`
+func RegisterCombiner(name string, combiner Combiner) {
+    combinersMu.Lock()
+    defer combinersMu.Unlock()
+
+    combiners[name] = combiner
+}
+
+// getCombiner returns a combiner that was requested via 'combine=name' option or a combiner to join values with separator via 'join=separator' option.
+// Values are joined as strings via convert and split back into count parts.
+// Separator can't be empty or hold ',' or ';', because they separate options and profiles of tag.
+func getCombiner(options tags.Options, count int, convert ConvertFunc) (Combiner, error) {
+    if name, ok := options.Value("combine"); ok {
+        combinersMu.RLock()
+        defer combinersMu.RUnlock()
+
+        if combiner, ok := combiners[name]; ok {
+            return combiner, nil
+        }
+
+        return Combiner{}, errors.New(fmt.Sprintf("Unknown combiner '%s'. You must register it via 'RegisterCombiner'.", name))
+    }
+
+    if separator, ok := options.Value("join"); ok {
+        if len(separator) == 0 || strings.ContainsAny(separator, ",;") {
+            return Combiner{}, errors.New(fmt.Sprintf("Invalid separator '%s' of 'join' option. Separator can't be empty or hold ',' or ';'. You must register own combiner via 'RegisterCombiner' for such separator.", separator))
+        }
+
+        return joinCombiner(separator, count, convert), nil
+    }
+
+    return Combiner{}, errors.New("You must provide 'join=separator' or 'combine=name' option for combined field.")
+}
+
+// combinerOption returns an option that was used to get combiner, e.g. 'join=-' or 'combine=date'. Separator with leading or trailing spaces is quoted to be visible, e.g. 'join=" "'.
+func combinerOption(options tags.Options) (string) {
+    if name, ok := options.Value("combine"); ok {
+        return "combine=" + name
+    }
+
+    separator, _ := options.Value("join")
+    if strings.TrimSpace(separator) != separator {
+        separator = strconv.Quote(separator)
+    }
+
+    return "join=" + separator
+}
+
+// joinCombiner returns a combiner that joins values of linked fields converted via convert with separator and splits it back into count parts.
+// Last part holds rest of value, e.g. 'Mary Ann Smith' is split via ' ' into 'Mary' and 'Ann Smith' for 2 fields.
+func joinCombiner(separator string, count int, convert ConvertFunc) (Combiner) {
+    return Combiner{
+        Combine: func(values []interface{}) (interface{}, error) {
+            parts := make([]string, len(values))
+            isEmpty := true
+
+            for i, value := range values {
+                if value == nil {
+                    continue
+                }
+
+                part, err := convert(reflect.ValueOf(value), reflect.TypeOf(""))
+                if err != nil {
+                    return nil, err
+                }
+
+                if part.IsValid() {
+                    parts[i] = part.String()
+                    isEmpty = false
+                }
+            }
+
+            if isEmpty {
+                return nil, nil
+            }
+
+            return strings.Join(parts, separator), nil
+        },
+        Split: func(value interface{}) ([]interface{}, error) {
+            s, err := convert(reflect.ValueOf(value), reflect.TypeOf(""))
+            if err != nil || !s.IsValid() {
+                return nil, err
+            }
+
+            parts := strings.SplitN(s.String(), separator, count)
+            values := make([]interface{}, len(parts))
+            for i, part := range parts {
+                values[i] = part
+            }
+
+            return values, nil
+        },
+    }
+}
+
+// resolveCombinedField configures a field of t to be combined from linked fields of linkedType with names or indexes
+func resolveCombinedField(t *mapperType, fieldName string, linkedType *mapperType, names []string, options tags.Options) (error) {
+    field := t.fields[fieldName]
+
+    combiner, err := getCombiner(options, len(names), t.config.ValueConverter)
+    if err != nil {
+        return err
+    }
+
+    combined := &combinedFields{combiner: combiner, option: combinerOption(options)}
+    for _, name := range names {
+        var linkedName string
+        var linkedField *mapperField
+
+        if index, err := strconv.Atoi(name); err == nil {
+            if linkedName, linkedField, err = resolveIndexField(linkedType, index, field.name); err != nil {
+                return err
+            }
+
+            if linkedField.reverseId >= 0 {
+                return errors.New(fmt.Sprintf("Index %d of %v is linked to '%s' already, but got '%s'.", index, linkedType.normalizedType, linkedField.reverseName, field.name))
+            }
+        } else {
+            linkedName = linkedType.fieldName(name)
+            if linkedField = linkedType.fields[linkedName]; linkedField == nil {
+                return errors.New(fmt.Sprintf("There is no field with name '%s' at %v", linkedName, linkedType.normalizedType))
+            }
+        }
+
+        linkedField.reverseId = field.id
+        linkedField.reverseName = fieldName
+        linkedField.combined = combined
+        combined.names = append(combined.names, linkedName)
+    }
+
+    field.resolveOptions(options)
+    field.combined = combined
+    return nil
+}
+
+// mapCombined maps values between combined fields of fromType and linked fields of toType in both directions with merge mode via compiled plan of toType. Returns names of fields that were set.
+// Keys of map with other case than names are resolved via keys.
+func mapCombined(fromType *mapperType, from reflect.Value, keys *mapKeys, toType *mapperType, to reflect.Value, mode MergeMode) ([]string, error) {
+    var mapped []string
+
+    //few fields -> one field
+    for _, step := range toType.plan.combine {
+        field := step.field
+
+        values := make([]interface{}, len(step.linked))
+        for i, linkedField := range step.linked {
+            if linkedField != nil {
+                if value := keys.get(fromType, from, linkedField.id, field.combined.names[i]); value.IsValid() && value.CanInterface() {
+                    values[i] = value.Interface()
+                }
+            }
+        }
+
+        combined, err := field.combined.combiner.Combine(values)
+        if err != nil {
+            return nil, errors.New(fmt.Sprintf("Could not combine '%s'. %s", field.name, err.Error()))
+        }
+
+        if combined == nil {
+            continue
+        }
+
+        value, err := convertCombined(field, reflect.ValueOf(combined), toType.get(to, field.id, step.name).Type())
+        if err != nil {
+            return nil, err
+        }
+
+        if isSet, err := mergeValue(toType, to, field.id, step.name, reflect.ValueOf(combined), value, mode); err != nil {
+            return nil, err
+        } else if isSet {
+            mapped = append(mapped, field.name)
+        }
+    }
+
+    //one field -> few fields
+    for _, step := range toType.plan.split {
+        field := step.field
+
+        value := keys.get(fromType, from, field.id, step.name)
+        if !value.IsValid() {
+            continue
+        }
+
+        values, err := field.combined.combiner.Split(value.Interface())
+        if err != nil {
+            return nil, errors.New(fmt.Sprintf("Could not split '%s'. %s", field.name, err.Error()))
+        }
+
+        for i, linkedField := range step.linked {
+            if linkedField == nil || i >= len(values) || values[i] == nil {
+                continue
+            }
+
+            name := field.combined.names[i]
+            value, err := convertCombined(linkedField, reflect.ValueOf(values[i]), toType.get(to, linkedField.id, name).Type())
+            if err != nil {
+                return nil, err
+            }
+
+            if isSet, err := mergeValue(toType, to, linkedField.id, name, reflect.ValueOf(values[i]), value, mode); err != nil {
+                return nil, err
+            } else if isSet {
+                mapped = append(mapped, linkedField.name)
+            }
+        }
+    }
+
+    return mapped, nil
+}
+
+// convertCombined converts a combined or split value to required type toType. Values of required type are used as is.
+func convertCombined(field *mapperField, value reflect.Value, toType reflect.Type) (reflect.Value, error) {
+    if value.Type().AssignableTo(toType) {
+        return value, nil
+    }
+
+    converted, err := field.convert(value, toType)
+    if err != nil {
+        return reflect.Value{}, errors.New(fmt.Sprintf("Could not convert '%s'. %s", field.name, err.Error()))
+    }
+
+    return converted, nil
+}
@@ -0,0 +1,106 @@
+package remapper
+
+import (
+    "testing"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestCombinedNamedArray(t *testing.T) {
+    type TestStructCombined struct {
+        Name string `remapper:"first_name+last_name,join= "`
+        Date string `remapper:"year+month+day,join=-"`
+    }
+
+    mapper, err := New(TestStructCombined{}, Slice([]string{}, []string{"day", "first_name", "month", "last_name", "year"}))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    s, err := mapper.Map([]string{"01", "John", "05", "Smith", "2017"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructCombined{"John Smith", "2017-05-01"}, s)
+
+    a, err := mapper.Map(s)
+    require.Nil(t, err)
+    assert.Equal(t, []string{"01", "John", "05", "Smith", "2017"}, a)
+
+    //last field gets rest of value
+    a, err = mapper.Map(TestStructCombined{"Mary Ann Smith", "2017-05-01"})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"01", "Mary", "05", "Ann Smith", "2017"}, a)
+
+    s, err = mapper.Map([]string{"", "", "", "", ""})
+    require.Nil(t, err)
+    assert.Nil(t, s)
+
+    bound, err := mapper.WithHeader([]string{"first_name", "last_name", "year", "month", "day"})
+    require.Nil(t, err)
+
+    s, err = bound.Map([]string{"John", "Smith", "2017", "05", "01"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructCombined{"John Smith", "2017-05-01"}, s)
+
+    //separator can't hold separators of tag
+    for _, mapping := range []string{"first_name+last_name,join=", "first_name+last_name,join=,", "first_name+last_name,join=;", "first_name+last_name,join=a;b"} {
+        _, err = New(TestStructCombined{}, Slice([]string{}, []string{"first_name", "last_name"}), map[string]string{"Name": mapping})
+        assert.NotNil(t, err, mapping)
+    }
+
+    type TestStructProfiledCombined struct {
+        Date string `remapper:"csv=year+month,join=;;db=date"`
+    }
+
+    _, err = New(TestStructProfiledCombined{}, Slice([]string{}, []string{"year", "month"}), Config{Profile: "csv"})
+    assert.NotNil(t, err)
+}
+
+func TestCombinedIndexedArray(t *testing.T) {
+    RegisterCombiner("sum", Combiner{
+        Combine: func(values []interface{}) (interface{}, error) {
+            sum := 0
+            for _, value := range values {
+                sum += value.(int)
+            }
+
+            return sum, nil
+        },
+        Split: func(value interface{}) ([]interface{}, error) {
+            return []interface{}{value, 0}, nil
+        },
+    })
+
+    type TestStructCombined struct {
+        Sum int `remapper:"1+2,combine=sum"`
+        Val int `remapper:"0"`
+    }
+
+    mapper, err := New([]interface{}{}, TestStructCombined{})
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    s, err := mapper.Map([]interface{}{1, 2, 3})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructCombined{5, 1}, s)
+
+    a, err := mapper.Map(s)
+    require.Nil(t, err)
+    assert.Equal(t, []interface{}{1, 5, 0}, a)
+
+    //unknown combiner
+    type TestStructUnknownCombiner struct {
+        Sum int `remapper:"1+2,combine=unknown"`
+    }
+
+    mapper, err = New([]interface{}{}, TestStructUnknownCombiner{})
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+
+    //no combiner
+    type TestStructNoCombiner struct {
+        Sum int `remapper:"1+2"`
+    }
+
+    mapper, err = New([]interface{}{}, TestStructNoCombiner{})
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+}
@@ -0,0 +1,41 @@
+package remapper
+
+// Config holds settings of mapper that can be provided at 'New'. Fields with zero values are replaced with package defaults, e.g. TagName.
+type Config struct {
+    // TagName is the name of the tag to use on struct fields. Default: TagName
+    TagName string
+
+    // NameMapper is the function used to convert name of fields. Default: NameMapper
+    NameMapper func(string) string
+
+    // ValueConverter is converter for values. Default: ValueConverter
+    ValueConverter ConvertFunc
+
+    // Merge is a way to merge values at 'MapInto'. Default: MergeOverwrite
+    Merge MergeMode
+
+    // Empty is a way to return a result without mapped fields. Default: EmptySkip
+    Empty EmptyPolicy
+
+    // Profile is a name of section of tags to use, e.g. 'csv' for `remapper:"csv=3;db=user_id"`. At least one field must have a section for profile.
+    // Fields without section for profile are linked via section without name of profile or are not linked.
+    // Default: section without name of profile, so each tag with profiles must have such section
+    Profile string
+}
+
+// resolve returns a copy of config with package defaults for fields that were not provided
+func (c Config) resolve() (*Config) {
+    if len(c.TagName) == 0 {
+        c.TagName = TagName
+    }
+
+    if c.NameMapper == nil {
+        c.NameMapper = NameMapper
+    }
+
+    if c.ValueConverter == nil {
+        c.ValueConverter = ValueConverter
+    }
+
+    return &c
+}
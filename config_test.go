@@ -0,0 +1,153 @@
+package remapper
+
+import (
+    "testing"
+    "fmt"
+    "reflect"
+    "strings"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+    type TestStructTags struct {
+        IntVal int    `remapper:"int_val" csv:"1"`
+        StrVal string `remapper:"str_val" csv:"0"`
+    }
+
+    //tag name
+    mapper, err := New(TestStructTags{}, []string{}, Config{TagName: "csv"})
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    arr, err := mapper.Map(TestStructTags{-1, "test string"})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"test string", "-1"}, arr)
+
+    //name mapper is used per mapper
+    mapper, err = New(TestStructTags{}, Map(map[string]string{}, []string{"INT_VAL", "STR_VAL"}), Config{NameMapper: strings.ToUpper})
+    require.Nil(t, err)
+
+    defaultMapper, err := New(TestStructTags{}, Map(map[string]string{}, []string{"int_val", "str_val"}))
+    require.Nil(t, err)
+
+    s, err := mapper.Map(map[string]string{"INT_VAL": "-1", "STR_VAL": "test string"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructTags{-1, "test string"}, s)
+
+    s, err = defaultMapper.Map(map[string]string{"int_val": "-1", "str_val": "test string"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructTags{-1, "test string"}, s)
+
+    //later changes of package defaults don't affect existing mappers
+    NameMapper = strings.ToUpper
+    defer func() { NameMapper = strings.ToLower }()
+
+    s, err = defaultMapper.Map(map[string]string{"int_val": "-1", "str_val": "test string"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructTags{-1, "test string"}, s)
+
+    //converter
+    converter := func(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
+        if toType.Kind() == reflect.String {
+            return reflect.ValueOf(fmt.Sprintf("<%v>", from.Interface())), nil
+        }
+
+        return Convert(from, toType)
+    }
+
+    mapper, err = New(TestStructTags{}, []string{}, Config{TagName: "csv", ValueConverter: converter})
+    require.Nil(t, err)
+
+    arr, err = mapper.Map(TestStructTags{-1, "test string"})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"<test string>", "<-1>"}, arr)
+
+    //policies
+    mapper, err = New(TestStructTags{}, []string{}, Config{TagName: "csv", Empty: EmptyError, Merge: MergeZero})
+    require.Nil(t, err)
+
+    _, err = mapper.Map([]string{"", ""})
+    assert.Equal(t, ErrEmpty, err)
+
+    st := TestStructTags{IntVal: 10}
+    require.Nil(t, mapper.MapInto([]string{"test string", "-1"}, &st))
+    assert.Equal(t, TestStructTags{10, "test string"}, st)
+}
+
+func TestTagProfiles(t *testing.T) {
+    type TestStructProfiles struct {
+        ID   int    `remapper:"csv=0;db=user_id;api=userId"`
+        Name string `remapper:"name;csv=1"`
+        Note string `remapper:"csv=2;api=note,omit"`
+    }
+
+    //csv
+    mapper, err := New(TestStructProfiles{}, []string{}, Config{Profile: "csv"})
+    require.Nil(t, err)
+
+    a, err := mapper.Map(TestStructProfiles{1, "John", "test"})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"1", "John", "test"}, a)
+
+    //db
+    mapper, err = New(TestStructProfiles{}, Map(map[string]string{}, nil), Config{Profile: "db"})
+    require.Nil(t, err)
+
+    m, err := mapper.Map(TestStructProfiles{1, "John", "test"})
+    require.Nil(t, err)
+    assert.Equal(t, map[string]string{"user_id": "1", "name": "John"}, m)
+
+    //api
+    mapper, err = New(TestStructProfiles{}, Map(map[string]interface{}{}, nil), Config{Profile: "api"})
+    require.Nil(t, err)
+
+    m, err = mapper.Map(TestStructProfiles{1, "John", "test"})
+    require.Nil(t, err)
+    assert.Equal(t, map[string]interface{}{"userId": 1, "name": "John"}, m)
+
+    //default section
+    type TestStructDefaultProfile struct {
+        ID   int    `remapper:"id;csv=0"`
+        Name string `remapper:"name;csv=1"`
+        Note string `remapper:"note"`
+    }
+
+    mapper, err = New(TestStructDefaultProfile{}, Map(map[string]string{}, nil))
+    require.Nil(t, err)
+
+    m, err = mapper.Map(TestStructDefaultProfile{1, "John", "test"})
+    require.Nil(t, err)
+    assert.Equal(t, map[string]string{"id": "1", "name": "John", "note": "test"}, m)
+
+    //profile must be selected for tags without default section
+    mapper, err = New(TestStructProfiles{}, Map(map[string]string{}, nil))
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+
+    type TestStructOnlyProfiles struct {
+        ID   int    `remapper:"csv=0;db=user_id;api=userId"`
+        Name string `remapper:"csv=1;db=name"`
+    }
+
+    mapper, err = New(TestStructOnlyProfiles{}, []string{})
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+
+    //unknown profile
+    mapper, err = New(TestStructProfiles{}, []string{}, Config{Profile: "cvs"})
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+
+    //profile of inlined struct
+    type TestStructInlineProfiles struct {
+        Profiles TestStructProfiles `remapper:",inline"`
+    }
+
+    mapper, err = New(TestStructInlineProfiles{}, []string{}, Config{Profile: "csv"})
+    require.Nil(t, err)
+
+    a, err = mapper.Map(TestStructInlineProfiles{TestStructProfiles{1, "John", "test"}})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"1", "John", "test"}, a)
+}
@@ -11,18 +11,41 @@ type ConvertFunc func(from reflect.Value, toType reflect.Type) (reflect.Value, e
 
 // Converts a value to required type toType or return error in case of failure. This function is using by default.
 func Convert(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
-    var err error
+    if from.Kind() == reflect.Interface {
+        //nothing to convert
+        if from.IsNil() {
+            return reflect.Value{}, nil
+        }
 
-    fromKind := from.Kind()
-    if fromKind == reflect.Interface {
         from = reflect.Indirect(from).Elem()
-        fromKind = from.Kind()
     }
 
     if toType.Kind() == reflect.Interface {
         toType = from.Type()
     }
+
     to := reflect.Indirect(reflect.New(toType))
+    if isSet, err := convertTo(from, to); err != nil || !isSet {
+        return reflect.Value{}, err
+    }
+
+    return to, nil
+}
+
+// convertTo converts a value and sets it to addressable value 'to' same way as Convert. Returns false if there is nothing to convert.
+func convertTo(from reflect.Value, to reflect.Value) (bool, error) {
+    var err error
+
+    fromKind := from.Kind()
+    if fromKind == reflect.Interface {
+        //nothing to convert
+        if from.IsNil() {
+            return false, nil
+        }
+
+        from = reflect.Indirect(from).Elem()
+        fromKind = from.Kind()
+    }
 
     switch to.Kind() {
 
@@ -33,7 +56,7 @@ func Convert(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
         case reflect.String:
             v = strings.TrimSpace(from.String())
             if len(v) == 0 {
-                return reflect.Value{}, nil
+                return false, nil
             }
         case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
             v = strconv.FormatInt(from.Int(), 10)
@@ -46,7 +69,7 @@ func Convert(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
         case reflect.Bool:
             v = strconv.FormatBool(from.Bool())
         default:
-            return reflect.Value{}, unsupportedType(from)
+            return false, unsupportedType(from)
         }
 
         to.SetString(v)
@@ -59,13 +82,13 @@ func Convert(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
         case reflect.String:
             s := strings.TrimSpace(from.String())
             if len(s) == 0 {
-                return reflect.Value{}, nil
+                return false, nil
             }
 
             if v, err = strconv.ParseInt(s, 10, 64); err != nil {
                 //If type of field is int, but value is float, then try to convert it
                 if vv, err2 := strconv.ParseFloat(s, 10); err2 != nil {
-                    return reflect.Value{}, err
+                    return false, err
                 } else {
                     v = int64(vv)
                 }
@@ -77,7 +100,7 @@ func Convert(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
         case reflect.Float32, reflect.Float64:
             v = int64(from.Float())
         default:
-            return reflect.Value{}, unsupportedType(from)
+            return false, unsupportedType(from)
         }
 
         to.SetInt(v)
@@ -90,13 +113,13 @@ func Convert(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
         case reflect.String:
             s := strings.TrimSpace(from.String())
             if len(s) == 0 {
-                return reflect.Value{}, nil
+                return false, nil
             }
 
             if v, err = strconv.ParseUint(s, 10, 64); err != nil {
                 //If type of field is uint, but value is float, then try to convert it
                 if vv, err2 := strconv.ParseFloat(s, 10); err2 != nil {
-                    return reflect.Value{}, err
+                    return false, err
                 } else {
                     v = uint64(vv)
                 }
@@ -108,7 +131,7 @@ func Convert(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
         case reflect.Float32, reflect.Float64:
             v = uint64(from.Float())
         default:
-            return reflect.Value{}, unsupportedType(from)
+            return false, unsupportedType(from)
         }
 
         to.SetUint(v)
@@ -121,11 +144,11 @@ func Convert(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
         case reflect.String:
             s := strings.Replace(strings.TrimSpace(from.String()), ",", ".", -1)
             if len(s) == 0 {
-                return reflect.Value{}, nil
+                return false, nil
             }
 
             if v, err = strconv.ParseFloat(s, 10); err != nil {
-                return reflect.Value{}, err
+                return false, err
             }
         case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
             v = float64(from.Int())
@@ -134,7 +157,7 @@ func Convert(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
         case reflect.Float32, reflect.Float64:
             v = from.Float()
         default:
-            return reflect.Value{}, unsupportedType(from)
+            return false, unsupportedType(from)
         }
 
         to.SetFloat(v)
@@ -147,11 +170,11 @@ func Convert(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
         case reflect.String:
             s := strings.TrimSpace(from.String())
             if len(s) == 0 {
-                return reflect.Value{}, nil
+                return false, nil
             }
 
             if v, err = strconv.ParseBool(s); err != nil {
-                return reflect.Value{}, err
+                return false, err
             }
         case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
             v = from.Int() != 0
@@ -162,14 +185,20 @@ func Convert(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
         case reflect.Bool:
             v = from.Bool()
         default:
-            return reflect.Value{}, unsupportedType(from)
+            return false, unsupportedType(from)
         }
 
         to.SetBool(v)
 
     default:
-        return reflect.Value{}, unsupportedType(to)
+        //value of same type can be used as is
+        if from.IsValid() && from.Type().AssignableTo(to.Type()) {
+            to.Set(from)
+            return true, nil
+        }
+
+        return false, unsupportedType(to)
     }
 
-    return to, nil
+    return true, nil
 }
@@ -356,6 +356,16 @@ func TestConverterBool(t *testing.T) {
     testConverter(t, true, boolType, true, false)
 }
 
+func TestConverterSameType(t *testing.T) {
+    type point struct {
+        X, Y int
+    }
+
+    testConverter(t, point{1, 2}, reflect.TypeOf(point{}), point{1, 2}, false)
+    testConverter(t, []int{1, 2}, reflect.TypeOf([]int{}), []int{1, 2}, false)
+    testConverter(t, point{1, 2}, reflect.TypeOf([]int{}), nil, true)
+}
+
 func testConverter(t *testing.T, from interface{}, toType reflect.Type, result interface{}, hasError bool) {
     to, err := remapper.Convert(reflect.ValueOf(from), toType)
 
@@ -6,8 +6,11 @@ import (
     "fmt"
     "strings"
     "strconv"
+
+    "github.com/plandem/remapper/internal/tags"
 )
 
+// Package defaults that are used by mapper if Config has no own settings. Mapper captures them at 'New', so later changes don't affect existing mappers.
 var (
     // TagName is the name of the tag to use on struct fields
     TagName string = "remapper"
@@ -21,7 +24,7 @@ var (
 
 type mapperTypeI interface {
     create() (reflect.Value, error)                                //Create a new data of required type
-    set(to reflect.Value, i int, name string, value reflect.Value) //Set value to
+    set(to reflect.Value, i int, name string, value reflect.Value) (error) //Set value to
     get(from reflect.Value, i int, name string) (reflect.Value)    //Get value from
 }
 
@@ -30,6 +33,49 @@ type mapperType struct {
     dataType       reflect.Type            //Holds original type of data
     normalizedType reflect.Type            //Holds normalized type of data - no ptr and etc
     fields         map[string]*mapperField //Holds info for fields what must be mapped
+    width          int                     //Holds length of a new data, e.g. for slice. Zero means the highest linked index is used
+    placeholder    reflect.Value           //Holds value for not linked positions of a new data, e.g. for slice
+    valueType      reflect.Type            //Holds type of values to store into untyped data, e.g. for map[string]interface{}
+    caseSensitive  bool                    //Holds flag to match names of fields as is, i.e. without NameMapper, e.g. for map
+    config         *Config                 //Holds settings of mapper, e.g. NameMapper
+    plan           *mappingPlan            //Holds compiled plan to map into type from linked type
+}
+
+// fieldName returns a name to look up a field with name
+func (t *mapperType) fieldName(name string) (string) {
+    if t.caseSensitive {
+        return name
+    }
+
+    return t.config.NameMapper(name)
+}
+
+// clone returns a copy of type with own copy of fields, so fields can be linked in other way without affecting the original type
+func (t *mapperType) clone() (*mapperType) {
+    c := *t
+    c.fields = make(map[string]*mapperField, len(t.fields))
+
+    for fieldName, field := range t.fields {
+        f := *field
+        c.fields[fieldName] = &f
+    }
+
+    switch t.mapperTypeI.(type) {
+    case *StructMapper:
+        m := StructMapper(c)
+        m.mapperTypeI = &m
+        c = mapperType(m)
+    case *SliceMapper:
+        m := SliceMapper(c)
+        m.mapperTypeI = &m
+        c = mapperType(m)
+    case *MapMapper:
+        m := MapMapper(c)
+        m.mapperTypeI = &m
+        c = mapperType(m)
+    }
+
+    return &c
 }
 
 func resolveType(v interface{}, types ...reflect.Kind) (reflect.Type, error) {
@@ -39,7 +85,7 @@ func resolveType(v interface{}, types ...reflect.Kind) (reflect.Type, error) {
         protoType = reflect.TypeOf(v)
 
         if protoType.Kind() == reflect.Ptr {
-            protoType = reflect.Indirect(reflect.ValueOf(v)).Type()
+            protoType = protoType.Elem()
         }
 
         for _, kind := range types {
@@ -53,7 +99,7 @@ func resolveType(v interface{}, types ...reflect.Kind) (reflect.Type, error) {
 }
 
 func resolveMappingField(fromType *mapperType, from string, toType *mapperType, to interface{}) (error) {
-    fromFieldName := NameMapper(from)
+    fromFieldName := fromType.fieldName(from)
 
     //is fromFieldName valid?
     if fromField, ok := fromType.fields[fromFieldName]; !ok {
@@ -61,8 +107,7 @@ func resolveMappingField(fromType *mapperType, from string, toType *mapperType,
     } else {
         //mapping by index?
         if toFieldId, ok := to.(int); ok {
-            fromField.reverseId = toFieldId
-            return nil
+            return linkIndexField(fromFieldName, fromField, toType, toFieldId, "")
         }
 
         //mapping by name or index with settings?
@@ -71,11 +116,37 @@ func resolveMappingField(fromType *mapperType, from string, toType *mapperType,
             return errors.New(fmt.Sprintf("Invalid type of field settings. Supports only int and string."))
         }
 
-        toFieldName, options := parseFieldMapping(toFieldMappingSettings)
+        toFieldName, options := tags.Parse(toFieldMappingSettings)
+
+        //field collects not linked data?
+        if options.Contains("rest") {
+            return resolveRestField(fromType, fromFieldName, toType, options)
+        }
+
+        //field is combined from few linked fields?
+        if strings.Contains(toFieldName, combinedSeparator) {
+            return resolveCombinedField(fromType, fromFieldName, toType, strings.Split(toFieldName, combinedSeparator), options)
+        }
+
+        //mapping of inlined struct?
+        if options.Contains("inline") {
+            if fromType.normalizedType.Kind() != reflect.Struct {
+                return errors.New(fmt.Sprintf("Only fields of struct can be inlined, but got '%s' at %v", fromFieldName, fromType.normalizedType))
+            }
+
+            prefix, _ := options.Value("prefix")
+            inlineMapping, err := (*StructMapper)(fromType).inline(fromFieldName, fromType.config.TagName, prefix)
+            if err != nil {
+                return err
+            }
+
+            return resolveMapping(fromType, toType, inlineMapping)
+        }
+
         if toFieldId, err := strconv.ParseInt(toFieldName, 10, 32); err == nil {
-            fromField.reverseId = int(toFieldId)
+            return linkIndexField(fromFieldName, fromField, toType, int(toFieldId), options)
         } else {
-            toFieldName := NameMapper(toFieldName)
+            toFieldName := toType.fieldName(toFieldName)
             if toField, ok := toType.fields[toFieldName]; !ok {
                 return errors.New(fmt.Sprintf("There is no field with name '%s' at %v", toFieldName, toType.normalizedType))
             } else {
@@ -94,6 +165,64 @@ func resolveMappingField(fromType *mapperType, from string, toType *mapperType,
     return nil
 }
 
+// resolveIndexField returns a name and field of t with index i. Fields of slice are registered on demand if width of slice allows it.
+func resolveIndexField(t *mapperType, i int, linkedFieldName string) (string, *mapperField, error) {
+    //map has no indexes, so index is a name of field, e.g. for map[int]string
+    if _, isMap := t.mapperTypeI.(*MapMapper); isMap {
+        fieldName := t.fieldName(strconv.Itoa(i))
+        if field, ok := t.fields[fieldName]; ok {
+            return fieldName, field, nil
+        }
+
+        return "", nil, errors.New(fmt.Sprintf("There is no field with name '%d' at %v", i, t.normalizedType))
+    }
+
+    for fieldName, field := range t.fields {
+        if field.id == i {
+            return fieldName, field, nil
+        }
+    }
+
+    if _, isSlice := t.mapperTypeI.(*SliceMapper); !isSlice || i < 0 || (t.width > 0 && i >= t.width) {
+        return "", nil, errors.New(fmt.Sprintf("Index %d of field '%s' is out of range of %v", i, linkedFieldName, t.normalizedType))
+    }
+
+    field := &mapperField{
+        id:        i,
+        convert:   t.config.ValueConverter,
+        reverseId: -1,
+    }
+
+    if err := t.addField(strconv.Itoa(i), field); err != nil {
+        return "", nil, err
+    }
+
+    return t.fieldName(field.name), field, nil
+}
+
+// linkIndexField links fromField to field of toType with index i
+func linkIndexField(fromFieldName string, fromField *mapperField, toType *mapperType, i int, options tags.Options) (error) {
+    toFieldName, toField, err := resolveIndexField(toType, i, fromField.name)
+    if err != nil {
+        return err
+    }
+
+    //N.B.: there is no reverse name for index, so only linked field knows a name. Map has no indexes, so index is a name.
+    if _, isMap := toType.mapperTypeI.(*MapMapper); isMap {
+        fromField.reverseName = toFieldName
+    } else if toField.reverseId >= 0 && toField.reverseId != fromField.id {
+        return errors.New(fmt.Sprintf("Index %d of %v is linked to '%s' already, but got '%s'.", i, toType.normalizedType, toField.reverseName, fromField.name))
+    }
+
+    fromField.reverseId = toField.id
+    toField.reverseId = fromField.id
+    toField.reverseName = fromFieldName
+
+    fromField.resolveOptions(options)
+    toField.resolveOptions(options)
+    return nil
+}
+
 func resolveMapping(fromType *mapperType, toType *mapperType, fromToMapping interface{}) (error) {
     if fromToMapping != nil {
         mappingVal := reflect.ValueOf(fromToMapping)
@@ -131,7 +260,9 @@ func resolveMapping(fromType *mapperType, toType *mapperType, fromToMapping inte
                 if isFromString {
                     err = resolveMappingField(fromType, fromString, toType, to)
                 } else if isToString {
-                    err = resolveMappingField(toType, toString, fromType, from)
+                    //options are related to link, so move them to the reverse side
+                    toName, options := tags.Parse(toString)
+                    err = resolveMappingField(toType, toName, fromType, tags.Join(fmt.Sprint(from), options))
                 } else {
                     err = errors.New("You can't map index to index.")
                 }
@@ -148,6 +279,19 @@ func resolveMapping(fromType *mapperType, toType *mapperType, fromToMapping inte
     return errors.New("Can't resolve mapping or invalid type of mapping. You must provide via 'mapping' argument or via tags.")
 }
 
+// addField registers a field with name or returns error if normalized name of field collides with already registered field
+func (t *mapperType) addField(name string, field *mapperField) (error) {
+    fieldName := t.fieldName(name)
+
+    if registered, ok := t.fields[fieldName]; ok {
+        return errors.New(fmt.Sprintf("Field name '%s' collides with '%s' at %v. Both are normalized to '%s'.", name, registered.name, t.normalizedType, fieldName))
+    }
+
+    field.name = name
+    t.fields[fieldName] = field
+    return nil
+}
+
 func unsupportedType(value reflect.Value) (error) {
     return errors.New(fmt.Sprintf("Unsupported type: %s", value.Kind()))
 }
@@ -0,0 +1,225 @@
+package remapper
+
+import (
+    "bytes"
+    "fmt"
+    "reflect"
+    "runtime"
+    "sort"
+    "strings"
+    "text/tabwriter"
+
+    "github.com/plandem/remapper/internal/tags"
+)
+
+// FieldLink describes a resolved link between field of source type and field of destination type
+type FieldLink struct {
+    From      string       // Name of source field as it was provided
+    FromIndex int          // Index of source field, e.g. position of struct or slice
+    FromType  reflect.Type // Type of source value. Nil if type is unknown, e.g. for slice without width
+    To        string       // Name of destination field as it was provided. For combined field it holds names of linked fields joined via '+'
+    ToIndex   int          // Index of destination field. -1 if there is no single destination field, e.g. for combined or rest fields
+    ToType    reflect.Type // Type of destination value. Nil if type is unknown
+    Converter string       // Name of function to convert values, e.g. 'remapper.Convert'. For combined field it holds option of combiner, e.g. 'join=-' or 'combine=date'
+    Options   []string     // Options of link, e.g. omit, required, rest, combined, join=- or prefix=billing_ for fields of inlined struct
+}
+
+// Description describes resolved configuration of mapper, i.e. links between fields of first and second types of mapper
+type Description struct {
+    From  reflect.Type
+    To    reflect.Type
+    Links []FieldLink
+}
+
+// Describe returns links between fields that were resolved at 'New'. Links are ordered by index of source field.
+// Links are described from first type to second type, but same links are used to map in both directions,
+// e.g. combined field is combined from linked fields via combiner and is split back into them via same combiner.
+func (m *Mapper) Describe() (Description) {
+    fromType, toType := m.types[0], m.types[1]
+    description := Description{From: fromType.dataType, To: toType.dataType}
+
+    //types of values are resolved via new data, e.g. for untyped slice. Data can't be created for slice without width, so types stay unknown.
+    from, _ := fromType.create()
+    to, _ := toType.create()
+
+    //linked field is resolved by id, because only one of linked fields knows a name for index
+    toFields := make(map[int]string, len(toType.fields))
+    for toName, toField := range toType.fields {
+        toFields[toField.id] = toName
+    }
+
+    for fromName, field := range fromType.fields {
+        link := FieldLink{
+            From:      field.name,
+            FromIndex: field.id,
+            FromType:  fromType.fieldType(from, field, fromName),
+            ToIndex:   -1,
+            Converter: converterName(field.convert),
+        }
+
+        switch {
+        case field.combined != nil && field.reverseId < 0:
+            names := make([]string, len(field.combined.names))
+            for i, name := range field.combined.names {
+                if linkedField, ok := toType.fields[name]; ok {
+                    name = linkedField.name
+                }
+
+                names[i] = name
+            }
+
+            link.To = strings.Join(names, combinedSeparator)
+            link.Converter = field.combined.option
+        case field.rest:
+        case field.reverseId >= 0:
+            toName, ok := toFields[field.reverseId]
+            if !ok {
+                continue
+            }
+
+            toField := toType.fields[toName]
+            link.To = toField.name
+            link.ToIndex = toField.id
+            link.ToType = toType.fieldType(to, toField, toName)
+            link.Converter = converterName(toField.convert)
+            link.Options = describeFieldOptions(toField)
+
+            if toField.combined != nil {
+                link.Converter = toField.combined.option
+            }
+        default:
+            //not linked
+            continue
+        }
+
+        link.Options = mergeOptions(link.Options, describeFieldOptions(field))
+        description.Links = append(description.Links, link)
+    }
+
+    sort.Slice(description.Links, func(i, j int) bool {
+        return description.Links[i].FromIndex < description.Links[j].FromIndex
+    })
+
+    return description
+}
+
+// String returns links as a table
+func (d Description) String() (string) {
+    var b bytes.Buffer
+
+    fmt.Fprintf(&b, "%s -> %s\n", d.From, d.To)
+
+    w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(w, "FROM\tINDEX\tTYPE\tTO\tINDEX\tTYPE\tCONVERTER\tOPTIONS")
+    for _, link := range d.Links {
+        fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+            link.From,
+            describeIndex(link.FromIndex),
+            describeType(link.FromType),
+            link.To,
+            describeIndex(link.ToIndex),
+            describeType(link.ToType),
+            link.Converter,
+            describeOptions(link.Options),
+        )
+    }
+
+    w.Flush()
+    return b.String()
+}
+
+// fieldType returns a type of value of field with name at v or nil if type is unknown
+func (t *mapperType) fieldType(v reflect.Value, field *mapperField, name string) (reflect.Type) {
+    //map has no values for keys of a new map
+    if _, isMap := t.mapperTypeI.(*MapMapper); isMap {
+        if t.valueType != nil {
+            return t.valueType
+        }
+
+        return t.normalizedType.Elem()
+    }
+
+    if v.IsValid() {
+        if value := t.get(v, field.id, name); value.IsValid() {
+            return value.Type()
+        }
+    }
+
+    return nil
+}
+
+// converterName returns a short name of function to convert values, e.g. 'remapper.Convert'
+func converterName(convert ConvertFunc) (string) {
+    if convert == nil {
+        return "-"
+    }
+
+    name := runtime.FuncForPC(reflect.ValueOf(convert).Pointer()).Name()
+    return name[strings.LastIndex(name, "/")+1:]
+}
+
+// describeFieldOptions returns all options of field, i.e. resolved flags, options as they were provided and options of inlining struct
+func describeFieldOptions(field *mapperField) ([]string) {
+    var options []string
+    for option, isSet := range map[string]bool{"omit": field.omit, "required": field.required, "rest": field.rest, "combined": field.combined != nil} {
+        if isSet {
+            options = append(options, option)
+        }
+    }
+
+    for _, provided := range []tags.Options{field.options, field.inlined} {
+        for _, option := range strings.Split(string(provided), ",") {
+            switch {
+            //'-' is a short form of 'omit'
+            case len(option) == 0 || option == "-":
+            //option of combiner is described as it was resolved
+            case field.combined != nil && (strings.HasPrefix(option, "join=") || strings.HasPrefix(option, "combine=")):
+                options = append(options, field.combined.option)
+            default:
+                options = append(options, option)
+            }
+        }
+    }
+
+    return options
+}
+
+// mergeOptions returns sorted options of both lists without duplicates
+func mergeOptions(options []string, other []string) ([]string) {
+    var merged []string
+    unique := make(map[string]bool)
+
+    for _, option := range append(options, other...) {
+        if !unique[option] {
+            unique[option] = true
+            merged = append(merged, option)
+        }
+    }
+
+    sort.Strings(merged)
+    return merged
+}
+
+func describeIndex(index int) (string) {
+    if index < 0 {
+        return "-"
+    }
+
+    return fmt.Sprint(index)
+}
+
+func describeType(t reflect.Type) (string) {
+    if t == nil {
+        return "-"
+    }
+
+    return t.String()
+}
+
+func describeOptions(options []string) (string) {
+    if len(options) == 0 {
+        return "-"
+    }
+
+    return strings.Join(options, ",")
+}
@@ -0,0 +1,93 @@
+package remapper
+
+import (
+    "testing"
+    "reflect"
+    "strings"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+    type TestStructDescribe struct {
+        IntVal int               `remapper:"int_val"`
+        StrVal string            `remapper:"str_val,omit"`
+        Date   string            `remapper:"year+month,join=-"`
+        Skip   string
+        Rest   map[string]string `remapper:",rest"`
+    }
+
+    mapper, err := New(Slice([]interface{}{}, []string{"int_val", "str_val", "month", "year"}), TestStructDescribe{})
+    require.Nil(t, err)
+
+    //links are described from first type to second type
+    description := mapper.Describe()
+    assert.Equal(t, reflect.TypeOf([]interface{}{}), description.From)
+    assert.Equal(t, reflect.TypeOf(TestStructDescribe{}), description.To)
+    require.Len(t, description.Links, 4)
+
+    assert.Equal(t, FieldLink{From: "int_val", FromIndex: 0, FromType: reflect.TypeOf((*interface{})(nil)).Elem(), To: "IntVal", ToIndex: 0, ToType: reflect.TypeOf(0), Converter: "remapper.Convert"}, description.Links[0])
+    assert.Equal(t, "StrVal", description.Links[1].To)
+    assert.Equal(t, []string{"omit"}, description.Links[1].Options)
+    assert.Equal(t, "month", description.Links[2].From)
+    assert.Equal(t, "Date", description.Links[2].To)
+    assert.Equal(t, 2, description.Links[2].ToIndex)
+    assert.Equal(t, "join=-", description.Links[2].Converter)
+    assert.Equal(t, []string{"combined", "join=-"}, description.Links[2].Options)
+    assert.Equal(t, "year", description.Links[3].From)
+    assert.Equal(t, "join=-", description.Links[3].Converter)
+
+    //combined and rest fields of struct
+    mapper, err = New(TestStructDescribe{}, Slice([]string{}, []string{"int_val", "str_val", "month", "year"}))
+    require.Nil(t, err)
+
+    description = mapper.Describe()
+    require.Len(t, description.Links, 4)
+    assert.Equal(t, "year+month", description.Links[2].To)
+    assert.Equal(t, -1, description.Links[2].ToIndex)
+    assert.Equal(t, "join=-", description.Links[2].Converter)
+    assert.Equal(t, []string{"combined", "join=-"}, description.Links[2].Options)
+    assert.Equal(t, "Rest", description.Links[3].From)
+    assert.Equal(t, "", description.Links[3].To)
+    assert.Equal(t, []string{"rest"}, description.Links[3].Options)
+
+    //map has types of values
+    mapper, err = New(TestStructNamed{}, Map(map[string]interface{}{}, MapOptions{Names: arrayFieldNames, ValueType: reflect.TypeOf("")}))
+    require.Nil(t, err)
+
+    description = mapper.Describe()
+    require.Len(t, description.Links, 5)
+    assert.Equal(t, reflect.TypeOf(""), description.Links[0].ToType)
+
+    //fields of inlined struct have options of inlining field with resulting prefix
+    type TestStructDescribeAddress struct {
+        City string `remapper:"city,required"`
+    }
+
+    type TestStructDescribeInline struct {
+        Billing TestStructDescribeAddress `remapper:",inline,prefix=billing_"`
+    }
+
+    mapper, err = New(TestStructDescribeInline{}, Map(map[string]string{}, []string{"billing_city"}))
+    require.Nil(t, err)
+
+    description = mapper.Describe()
+    require.Len(t, description.Links, 1)
+    assert.Equal(t, "billing_city", description.Links[0].To)
+    assert.Equal(t, []string{"inline", "prefix=billing_", "required"}, description.Links[0].Options)
+
+    //custom converter
+    converter := func(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
+        return Convert(from, toType)
+    }
+
+    mapper, err = New(TestStructNamed{}, Slice([]string{}, arrayFieldNames), Config{ValueConverter: converter})
+    require.Nil(t, err)
+
+    description = mapper.Describe()
+    assert.Contains(t, description.Links[0].Converter, "TestDescribe")
+
+    table := description.String()
+    assert.True(t, strings.HasPrefix(table, "remapper.TestStructNamed -> []string\nFROM "))
+    assert.Len(t, strings.Split(strings.TrimSpace(table), "\n"), 7)
+}
@@ -0,0 +1,51 @@
+package remapper
+
+import (
+    "errors"
+    "sort"
+)
+
+// ErrEmpty is returned for result without mapped fields if mapper uses EmptyError policy
+var ErrEmpty = errors.New("Nothing was mapped.")
+
+// EmptyPolicy is a way to return a result of mapping that has no mapped fields, e.g. for row of blanks
+type EmptyPolicy int
+
+const (
+    // EmptySkip skips a result, i.e. nil is returned without error. Default.
+    EmptySkip EmptyPolicy = iota
+
+    // EmptyZero returns a new data with zero values
+    EmptyZero
+
+    // EmptyError returns ErrEmpty
+    EmptyError
+)
+
+// Result is a result of mapping with names of fields that were set
+type Result struct {
+    // Value is a result of mapping same as at 'Map'
+    Value interface{}
+
+    // Fields are names of fields of result that were set, in order of fields. Not linked data is reported by names or indexes of positions.
+    Fields []string
+}
+
+// sortFieldNames sorts names in order of fields of t. Names without fields, e.g. keys of not linked data, are sorted by names at the end.
+func sortFieldNames(t *mapperType, names []string) {
+    order := func(name string) (int) {
+        if field, ok := t.fields[t.fieldName(name)]; ok {
+            return field.id
+        }
+
+        return len(t.fields)
+    }
+
+    sort.SliceStable(names, func(i, j int) bool {
+        if order(names[i]) == order(names[j]) {
+            return names[i] < names[j]
+        }
+
+        return order(names[i]) < order(names[j])
+    })
+}
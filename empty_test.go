@@ -0,0 +1,84 @@
+package remapper
+
+import (
+    "testing"
+    "errors"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestEmptyPolicy(t *testing.T) {
+    mapper, err := New(TestStructNamed{}, Slice([]string{}, arrayFieldNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    blanks := make([]string, len(arrayFieldNames))
+
+    //skip
+    s, err := mapper.Map(blanks)
+    require.Nil(t, err)
+    assert.Nil(t, s)
+
+    //zero value
+    s, err = mapper.WithEmpty(EmptyZero).Map(blanks)
+    require.Nil(t, err)
+    assert.Equal(t, TestStructNamed{}, s)
+
+    arr, err := mapper.WithEmpty(EmptyZero).Map(TestStructNamed{})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"", "0", "0", "", "", "0.0000", "false", ""}, arr)
+
+    //error
+    s, err = mapper.WithEmpty(EmptyError).Map(blanks)
+    assert.Equal(t, ErrEmpty, err)
+    assert.Nil(t, s)
+
+    err = mapper.WithEmpty(EmptyError).MapInto(blanks, &TestStructNamed{})
+    assert.Equal(t, ErrEmpty, err)
+
+    _, err = mapper.WithEmpty(EmptyError).MapAll([][]string{arrayTyped, blanks}, BatchOptions{})
+    require.NotNil(t, err)
+    assert.True(t, errors.Is(err, ErrEmpty))
+
+    //mapped row is not affected
+    s, err = mapper.WithEmpty(EmptyError).Map(arrayTyped)
+    require.Nil(t, err)
+    assert.Equal(t, mappedNamedStruct, s)
+}
+
+func TestMapResult(t *testing.T) {
+    mapper, err := New(TestStructNamed{}, Slice([]string{}, arrayFieldNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    result, err := mapper.MapResult([]string{"", "-1", "", "", "test string", "", "", ""})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructNamed{IntVal: -1, StrVal: "test string"}, result.Value)
+    assert.Equal(t, []string{"IntVal", "StrVal"}, result.Fields)
+
+    result, err = mapper.MapResult(mappedNamedStruct)
+    require.Nil(t, err)
+    assert.Equal(t, []string{"int_val", "uint_val", "str_val", "float_val", "bool_val"}, result.Fields)
+
+    result, err = mapper.MapResult(make([]string, len(arrayFieldNames)))
+    require.Nil(t, err)
+    assert.Nil(t, result.Value)
+    assert.Len(t, result.Fields, 0)
+
+    //not linked data
+    type TestStructRest struct {
+        IntVal int      `remapper:"int_val"`
+        Rest   []string `remapper:",rest"`
+    }
+
+    mapper, err = New(TestStructRest{}, Slice([]string{}, []string{"int_val", "unknown1", "unknown2"}))
+    require.Nil(t, err)
+
+    result, err = mapper.MapResult(TestStructRest{IntVal: -1, Rest: []string{"a", "b"}})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"int_val", "unknown1", "unknown2"}, result.Fields)
+
+    result, err = mapper.MapResult([]string{"-1", "a", "b"})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"IntVal", "Rest"}, result.Fields)
+}
@@ -219,4 +219,78 @@ func Example_untypedSliceAndStruct() {
 
     //Output:
     // &{-1 1 test string 1.2345 true} <nil>
-}
\ No newline at end of file
+}
+
+func ExampleMapper_WithHeader() {
+    type MyStruct struct {
+        IntVal int    `remapper:"int_val,required"`
+        StrVal string `remapper:"str_val"`
+    }
+
+    mapper, err := remapper.New(&MyStruct{}, remapper.Slice([]string{}, []string{"int_val", "str_val"}))
+    if err != nil {
+        panic(err)
+    }
+
+    // bind mapper to columns of header
+    bound, err := mapper.WithHeader([]string{"str_val", "int_val"})
+    if err != nil {
+        panic(err)
+    }
+
+    // convert slice -> struct
+    s, err := bound.Map([]string{"test string", "-1"})
+    fmt.Println(s, err)
+
+    //Output:
+    // &{-1 test string} <nil>
+}
+
+func ExampleMapper_MapInto() {
+    type MyStruct struct {
+        IntVal int    `remapper:"int_val"`
+        StrVal string `remapper:"str_val"`
+        Note   string
+    }
+
+    mapper, err := remapper.New(&MyStruct{}, remapper.Slice([]string{}, []string{"int_val", "str_val"}))
+    if err != nil {
+        panic(err)
+    }
+
+    // convert slice -> existing struct
+    s := MyStruct{IntVal: 1, StrVal: "old string", Note: "untouched"}
+    err = mapper.MapInto([]string{"-1", "test string"}, &s)
+    fmt.Println(s, err)
+
+    // fill only fields with zero values
+    s = MyStruct{StrVal: "old string"}
+    err = mapper.WithMerge(remapper.MergeZero).MapInto([]string{"-1", "test string"}, &s)
+    fmt.Println(s, err)
+
+    //Output:
+    // {-1 test string untouched} <nil>
+    // {-1 old string } <nil>
+}
+
+func ExampleMapper_Describe() {
+    type MyStruct struct {
+        IntVal int    `remapper:"int_val"`
+        StrVal string `remapper:"str_val,required"`
+        Name   string `remapper:"first_name+last_name,join= "`
+    }
+
+    mapper, err := remapper.New(&MyStruct{}, remapper.Slice([]string{}, []string{"int_val", "str_val", "first_name", "last_name"}))
+    if err != nil {
+        panic(err)
+    }
+
+    fmt.Print(mapper.Describe())
+
+    //Output:
+    // *remapper_test.MyStruct -> []string
+    // FROM    INDEX  TYPE    TO                    INDEX  TYPE    CONVERTER         OPTIONS
+    // IntVal  0      int     int_val               0      string  remapper.Convert  -
+    // StrVal  1      string  str_val               1      string  remapper.Convert  required
+    // Name    2      string  first_name+last_name  -      -       join=" "          combined,join=" "
+}
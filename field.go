@@ -1,10 +1,25 @@
 package remapper
 
+import (
+    "reflect"
+
+    "github.com/plandem/remapper/internal/tags"
+)
+
 // mapperField hold minimal information to map between two fields
 type mapperField struct {
     // ID of field
     id int
 
+    // Name of field as it was provided, i.e. before normalization via NameMapper
+    name string
+
+    // Key of field at map, i.e. name that was converted to type of keys
+    key reflect.Value
+
+    // Index sequence of field at struct. For fields of inlined structs it holds a full path, e.g.: [2 0]
+    index []int
+
     // Reverse ID of field
     reverseId int
 
@@ -17,11 +32,33 @@ type mapperField struct {
     // Default: false
     omit bool
 
+    // Field must be present at linked data, e.g.: column of slice that is bound to header via 'WithHeader'
+    //
+    // Default: false
+    required bool
+
+    // Field collects data that is not linked to any other field, e.g.: unknown columns of slice or keys of map
+    //
+    // Default: false
+    rest bool
+
+    // Linked fields of field that is combined from few fields or a combined field that is linked to this one
+    combined *combinedFields
+
     // Function that will be using to convert value for this field. Default: Convert
     convert ConvertFunc
+
+    // Options of link as they were provided, e.g. to export a configuration of mapper
+    options tags.Options
+
+    // Options of struct field that inlines this field with resulting prefix, e.g. 'inline,prefix=billing_', to describe a configuration of mapper
+    inlined tags.Options
 }
 
 // resolveOptions configures a field with provided options
-func (f *mapperField) resolveOptions(options mappingOptions) {
+func (f *mapperField) resolveOptions(options tags.Options) {
     f.omit = options.Contains("omit") || options.Contains("-")
+    f.required = options.Contains("required")
+    f.rest = options.Contains("rest")
+    f.options = options
 }
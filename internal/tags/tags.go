@@ -0,0 +1,125 @@
+// Package tags holds a grammar of tags that is shared by remapper and remapper-gen, e.g.: 'user_id,required' or 'first_name+last_name,join= '
+package tags
+
+import (
+    "strings"
+    "unicode"
+)
+
+// Options is comma separated list of additional options for mapping between two fields.
+type Options string
+
+// Parse returns a 'reverse' name of field and additional options of field mapping
+func Parse(fieldMapping string) (string, Options) {
+    if idx := strings.Index(fieldMapping, ","); idx != -1 {
+        return fieldMapping[:idx], Options(fieldMapping[idx+1:])
+    }
+
+    return fieldMapping, Options("")
+}
+
+// Join returns a field mapping with 'reverse' name of field and additional options
+func Join(name string, options Options) (string) {
+    if len(options) > 0 {
+        return name + "," + string(options)
+    }
+
+    return name
+}
+
+// Contains returns true/false if options with name was set
+func (o Options) Contains(name string) bool {
+    if len(o) > 0 {
+        s := string(o)
+
+        for s != "" {
+            var next string
+            i := strings.Index(s, ",")
+
+            if i >= 0 {
+                s, next = s[:i], s[i+1:]
+            }
+
+            if s == name {
+                return true
+            }
+
+            s = next
+        }
+    }
+
+    return false
+}
+
+// Value returns a value of option with name that was set as 'name=value' and true/false if option was set
+func (o Options) Value(name string) (string, bool) {
+    prefix := name + "="
+
+    for _, option := range strings.Split(string(o), ",") {
+        if strings.HasPrefix(option, prefix) {
+            return option[len(prefix):], true
+        }
+    }
+
+    return "", false
+}
+
+// profileSeparator is a separator of profiles of tag, e.g.: 'csv=3;db=user_id'
+const profileSeparator = ";"
+
+// Profile returns a field mapping from tag for profile. Tag can hold few profiles, e.g.: 'csv=3;db=user_id;api=userId,omit'.
+// Section without name of profile is used if profile was not provided or tag has no section for profile, e.g.: 'user_id;csv=3'.
+// Tag without profiles is used as is for any profile.
+func Profile(tag string, profile string) (string) {
+    sections := strings.Split(tag, profileSeparator)
+    isProfiled := false
+
+    for _, section := range sections {
+        if name, mapping, ok := splitProfile(section); ok {
+            isProfiled = true
+
+            if len(profile) > 0 && name == profile {
+                return mapping
+            }
+        }
+    }
+
+    if !isProfiled {
+        return tag
+    }
+
+    for _, section := range sections {
+        if _, _, ok := splitProfile(section); !ok && len(section) > 0 {
+            return section
+        }
+    }
+
+    return ""
+}
+
+// HasProfile returns true if tag has a section for profile, e.g.: 'csv=3;db=user_id' has sections for 'csv' and 'db'
+func HasProfile(tag string, profile string) (bool) {
+    for _, section := range strings.Split(tag, profileSeparator) {
+        if name, _, ok := splitProfile(section); ok && name == profile {
+            return true
+        }
+    }
+
+    return false
+}
+
+// splitProfile returns a name of profile and a field mapping of section or false if section has no name of profile
+func splitProfile(section string) (string, string, bool) {
+    idx := strings.Index(section, "=")
+    if idx <= 0 {
+        return "", "", false
+    }
+
+    for _, r := range section[:idx] {
+        if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+            return "", "", false
+        }
+    }
+
+    return section[:idx], section[idx+1:], true
+}
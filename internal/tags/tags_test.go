@@ -0,0 +1,94 @@
+package tags
+
+import (
+    "testing"
+    "github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+    for mapping, expected := range map[string][2]string{
+        "":                                {"", ""},
+        "user_id":                         {"user_id", ""},
+        "user_id,required":                {"user_id", "required"},
+        ",rest":                           {"", "rest"},
+        "a+b,join=-,required":             {"a+b", "join=-,required"},
+        "date,combine=a=b":                {"date", "combine=a=b"},
+        ",inline,prefix=billing_":         {"", "inline,prefix=billing_"},
+    } {
+        name, options := Parse(mapping)
+        assert.Equal(t, expected[0], name, mapping)
+        assert.Equal(t, Options(expected[1]), options, mapping)
+        assert.Equal(t, mapping, Join(name, options), mapping)
+    }
+
+    assert.Equal(t, "user_id", Join("user_id", ""))
+}
+
+func TestOptions(t *testing.T) {
+    options := Options("required,join=-,combine=a=b,prefix=")
+
+    assert.True(t, options.Contains("required"))
+    assert.False(t, options.Contains("join"))
+    assert.False(t, options.Contains("req"))
+    assert.False(t, Options("").Contains(""))
+
+    for name, expected := range map[string]string{"join": "-", "combine": "a=b", "prefix": ""} {
+        value, ok := options.Value(name)
+        assert.True(t, ok, name)
+        assert.Equal(t, expected, value, name)
+    }
+
+    _, ok := options.Value("required")
+    assert.False(t, ok)
+
+    _, ok = options.Value("omit")
+    assert.False(t, ok)
+}
+
+func TestProfile(t *testing.T) {
+    for tag, expected := range map[string][2]string{
+        "":                                 {"", ""},
+        "user_id":                          {"user_id", "user_id"},
+        "csv=3;db=user_id;api=userId,omit": {"", "3"},
+        "user_id;csv=3":                    {"user_id", "3"},
+        "db=user_id;name":                  {"name", "name"},
+        "csv=;db=user_id":                  {"", ""},
+        "a+b,join=;":                       {"a+b,join=;", "a+b,join=;"},
+        ",inline,prefix=billing_":          {",inline,prefix=billing_", ",inline,prefix=billing_"},
+        "date,combine=a=b":                 {"date,combine=a=b", "date,combine=a=b"},
+        "csv=date,combine=a=b;date":        {"date", "date,combine=a=b"},
+        "csv.v2=3;id":                      {"csv.v2=3;id", "csv.v2=3;id"},
+    } {
+        assert.Equal(t, expected[0], Profile(tag, ""), tag)
+        assert.Equal(t, expected[1], Profile(tag, "csv"), tag)
+    }
+}
+
+func TestHasProfile(t *testing.T) {
+    assert.True(t, HasProfile("csv=3;db=user_id", "csv"))
+    assert.True(t, HasProfile("csv=3;db=user_id", "db"))
+    assert.True(t, HasProfile("csv=;id", "csv"))
+    assert.False(t, HasProfile("csv=3;db=user_id", "api"))
+    assert.False(t, HasProfile("user_id", "user_id"))
+    assert.False(t, HasProfile("date,combine=csv", "csv"))
+    assert.False(t, HasProfile("", ""))
+}
+
+func TestSplitProfile(t *testing.T) {
+    for section, expected := range map[string][3]interface{}{
+        "csv=3":             {"csv", "3", true},
+        "csv_v2=a,omit":     {"csv_v2", "a,omit", true},
+        "my-api=a=b":        {"my-api", "a=b", true},
+        "csv=":              {"csv", "", true},
+        "=3":                {"", "", false},
+        "3":                 {"", "", false},
+        "a,combine=b":       {"", "", false},
+        "a+b=c":             {"", "", false},
+        "":                  {"", "", false},
+    } {
+        name, mapping, ok := splitProfile(section)
+        assert.Equal(t, expected[0], name, section)
+        assert.Equal(t, expected[1], mapping, section)
+        assert.Equal(t, expected[2], ok, section)
+    }
+}
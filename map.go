@@ -1,51 +1,259 @@
 package remapper
 
 import (
+    "encoding"
+    "errors"
+    "fmt"
     "reflect"
+    "sort"
+    "strings"
+
+    "github.com/plandem/remapper/internal/tags"
 )
 
+// MapMapper is mapper to convert from/to map
+//
+// map can be:
+// - typed: i.e. map has type of values, e.g. map[string]string
+// - untyped: i.e. map has no any type of values, e.g. map[string]interface{}. Values are stored with own type or with type that was provided via MapOptions.
+//
+// keys of map can be any type that names can be converted to, e.g. map[int]string, or type that implements encoding.TextUnmarshaler.
 type MapMapper mapperType
 
-func newMapMapper(dataType reflect.Type, normalizedType reflect.Type, names []string) (mapperType) {
+var (
+    textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+    textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// MapOptions holds settings of map that can be used instead of names at 'Map'
+type MapOptions struct {
+    // Names of fields, i.e. keys of map
+    Names []string
+
+    // ValueType is a type of values to store into untyped map, e.g.: reflect.TypeOf(""). Default: own type of value
+    ValueType reflect.Type
+
+    // CaseSensitive enables matching of keys as is, e.g. to map keys that differ only by case. Default: keys are matched via NameMapper
+    CaseSensitive bool
+}
+
+// newMapMapper creates a new MapMapper that configured with options to map from/to map
+//
+// - you must provide a 'field names' via []string. E.g.: []string{"first_name", "birthday",...}
+// - or you can provide MapOptions with names and type of values for untyped map
+func newMapMapper(dataType reflect.Type, normalizedType reflect.Type, options interface{}, config *Config) (mapperType, error) {
     m := MapMapper{
         fields:         map[string]*mapperField{},
         dataType:       dataType,
         normalizedType: normalizedType,
+        config:         config,
+    }
+
+    var names []string
+    switch options := options.(type) {
+    case []string:
+        names = options
+    case MapOptions:
+        names = options.Names
+
+        if options.ValueType != nil {
+            if !options.ValueType.AssignableTo(normalizedType.Elem()) {
+                return mapperType{}, errors.New(fmt.Sprintf("Invalid type '%s' of values for %v", options.ValueType, normalizedType))
+            }
+
+            m.valueType = options.ValueType
+        }
+
+        m.caseSensitive = options.CaseSensitive
     }
 
     for fieldIndex, fieldName := range names {
-        m.fields[NameMapper(fieldName)] = &mapperField{
-            id:        fieldIndex,
-            convert:   ValueConverter,
-            reverseId: -1,
+        if err := m.addKey(fieldName, fieldIndex); err != nil {
+            return mapperType{}, err
         }
     }
 
     m.mapperTypeI = &m
-    return mapperType(m)
+    return mapperType(m), nil
+}
+
+// deriveNames registers fields of map with names that are linked via mapping from tags tagName of struct t or with names of fields that have no tags.
+// Returns a mapping that links fields of struct without tags via own names.
+func (m *MapMapper) deriveNames(t *mapperType, tagName string, mapping map[string]string) (map[string]string, error) {
+    for i, i_max := 0, t.normalizedType.NumField(); i < i_max; i++ {
+        f := t.normalizedType.Field(i)
+
+        if _, hasTag := f.Tag.Lookup(tagName); !hasTag && len(f.PkgPath) == 0 {
+            mapping[t.fieldName(f.Name)] = f.Name
+        }
+    }
+
+    var names []string
+    for _, fieldMapping := range mapping {
+        name, options := tags.Parse(fieldMapping)
+        if options.Contains("rest") || options.Contains("inline") {
+            continue
+        }
+
+        for _, name := range strings.Split(name, combinedSeparator) {
+            if len(name) > 0 {
+                names = append(names, name)
+            }
+        }
+    }
+
+    sort.Strings(names)
+    for _, name := range names {
+        //few fields of struct can be linked to same name
+        if _, ok := m.fields[(*mapperType)(m).fieldName(name)]; ok {
+            continue
+        }
+
+        if err := m.addKey(name, len(m.fields)); err != nil {
+            return nil, err
+        }
+    }
+
+    return mapping, nil
+}
+
+// addKey registers a field with name and id. Name is converted to type of keys of map as is, i.e. key keeps original case of name.
+func (m *MapMapper) addKey(name string, id int) (error) {
+    key, err := m.resolveKey(name)
+    if err != nil {
+        return err
+    }
+
+    return (*mapperType)(m).addField(name, &mapperField{
+        id:        id,
+        key:       key,
+        convert:   m.config.ValueConverter,
+        reverseId: -1,
+    })
+}
+
+// resolveKey converts a name to type of keys of map
+func (m *MapMapper) resolveKey(name string) (reflect.Value, error) {
+    keyType := m.normalizedType.Key()
+
+    switch {
+    case reflect.PtrTo(keyType).Implements(textUnmarshalerType):
+        key := reflect.New(keyType)
+        if err := key.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(name)); err != nil {
+            return reflect.Value{}, errors.New(fmt.Sprintf("Could not convert name '%s' to key of %v. %s", name, m.normalizedType, err.Error()))
+        }
+
+        return key.Elem(), nil
+    case keyType.Kind() == reflect.String:
+        return reflect.ValueOf(name).Convert(keyType), nil
+    }
+
+    key, err := m.config.ValueConverter(reflect.ValueOf(name), keyType)
+    if err != nil || !key.IsValid() {
+        return reflect.Value{}, errors.New(fmt.Sprintf("Could not convert name '%s' to key of %v", name, m.normalizedType))
+    }
+
+    return key, nil
+}
+
+// keyName converts a key of map to name
+func (m *MapMapper) keyName(key reflect.Value) (string) {
+    switch {
+    case key.Type().Implements(textMarshalerType):
+        if text, err := key.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+            return string(text)
+        }
+    case key.Kind() == reflect.String:
+        return key.String()
+    }
+
+    if name, err := m.config.ValueConverter(key, reflect.TypeOf("")); err == nil && name.IsValid() {
+        return name.String()
+    }
+
+    return fmt.Sprint(key.Interface())
+}
+
+// mapKeys is an index of keys of map by names, so keys with other case than names are resolved without scan of keys for each name
+type mapKeys struct {
+    mapper *MapMapper
+    from   reflect.Value
+    index  map[string]reflect.Value //Holds keys by normalized names. Index is built on first key that was not found as is.
+}
+
+// newMapKeys returns an index of keys of map from of t or nil if t is not a map with case-insensitive keys
+func newMapKeys(t *mapperType, from reflect.Value) (*mapKeys) {
+    if m, isMap := t.mapperTypeI.(*MapMapper); isMap && !t.caseSensitive {
+        return &mapKeys{mapper: m, from: from}
+    }
+
+    return nil
+}
+
+// get returns a value of field of t with id and name. Keys of map are resolved via index if k is not nil.
+func (k *mapKeys) get(t *mapperType, from reflect.Value, id int, name string) (reflect.Value) {
+    if k == nil {
+        return t.get(from, id, name)
+    }
+
+    return k.mapper.lookup(from, name, k)
+}
+
+// key returns a key of map with normalized name
+func (k *mapKeys) key(name string) (reflect.Value, bool) {
+    if k.index == nil {
+        k.index = make(map[string]reflect.Value, k.from.Len())
+        for _, key := range k.from.MapKeys() {
+            k.index[k.mapper.config.NameMapper(k.mapper.keyName(key))] = key
+        }
+    }
+
+    key, ok := k.index[name]
+    return key, ok
 }
 
 // creates a new instance of map of required type.
 func (m *MapMapper) create() (reflect.Value, error) {
-    return reflect.MakeMap(m.dataType), nil
+    to := reflect.New(m.normalizedType).Elem()
+    to.Set(reflect.MakeMap(m.normalizedType))
+    return to, nil
 }
 
 // sets a value to a map at index with name
-func (m *MapMapper) set(to reflect.Value, i int, name string, value reflect.Value) {
-    name = NameMapper(name)
-    if _, ok := m.fields[name]; ok {
-        to.SetMapIndex(reflect.ValueOf(name), value)
+func (m *MapMapper) set(to reflect.Value, i int, name string, value reflect.Value) (error) {
+    name = (*mapperType)(m).fieldName(name)
+    if field, ok := m.fields[name]; !ok {
+        return unknownFieldName(name)
+    } else {
+        to.SetMapIndex(field.key, value)
+        return nil
     }
 }
 
 // gets a value from a map at index with name
 func (m *MapMapper) get(from reflect.Value, i int, name string) (reflect.Value) {
-    name = NameMapper(name)
-    if _, ok := m.fields[name]; ok {
-        v := from.MapIndex(reflect.ValueOf(name))
+    return m.lookup(from, name, &mapKeys{mapper: m, from: from})
+}
+
+// lookup returns a value of map from with name. Keys with other case than name are resolved via keys.
+func (m *MapMapper) lookup(from reflect.Value, name string, keys *mapKeys) (reflect.Value) {
+    name = (*mapperType)(m).fieldName(name)
+    if field, ok := m.fields[name]; ok {
+        v := from.MapIndex(field.key)
+
+        //key can have other case than name
+        if !v.IsValid() && !m.caseSensitive {
+            if key, ok := keys.key(name); ok {
+                v = from.MapIndex(key)
+            }
+        }
 
         if !v.IsValid() {
-            v = reflect.Zero(m.dataType.Elem())
+            if m.valueType != nil {
+                v = reflect.Zero(m.valueType)
+            } else {
+                v = reflect.Zero(m.normalizedType.Elem())
+            }
         }
 
         return v
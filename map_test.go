@@ -3,6 +3,7 @@ package remapper
 import (
     "testing"
     "reflect"
+    "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/require"
 )
 
@@ -20,10 +21,40 @@ func TestMapMapper(t *testing.T) {
     require.Nil(t, err)
     require.Equal(t, reflect.Map, dataNormalizedType.Kind())
 
-    mapper := newMapMapper(dataType, dataNormalizedType, mapNames)
+    mapper, err := newMapMapper(dataType, dataNormalizedType, mapNames, Config{}.resolve())
+    require.Nil(t, err)
     testTypedMapMethods(t, mapper, dataVal)
 
-    //untyped map is not possible, so no tests for it.
+    //untyped map
+    data1 := map[string]interface{}{"intval": int(0), "uintval": uint(0), "strval": "", "floatval": 0.0, "boolval": false}
+    data1Val := reflect.Indirect(reflect.ValueOf(&data1))
+    dataType = reflect.TypeOf(data1)
+
+    dataNormalizedType, err = resolveType(data1, reflect.Map)
+    require.Nil(t, err)
+
+    mapper, err = newMapMapper(dataType, dataNormalizedType, mapNames, Config{}.resolve())
+    require.Nil(t, err)
+    testUntypedMapMethods(t, mapper, data1Val)
+
+    //untyped map with type of values
+    mapper, err = newMapMapper(dataType, dataNormalizedType, MapOptions{Names: mapNames, ValueType: reflect.TypeOf("")}, Config{}.resolve())
+    require.Nil(t, err)
+    assert.Equal(t, reflect.TypeOf(""), mapper.get(reflect.ValueOf(map[string]interface{}{}), 0, "IntVal").Type())
+
+    //typed map with invalid type of values
+    _, err = newMapMapper(reflect.TypeOf(data), reflect.TypeOf(data), MapOptions{Names: mapNames, ValueType: reflect.TypeOf(0)}, Config{}.resolve())
+    assert.NotNil(t, err)
+}
+
+func TestMapMapperCollision(t *testing.T) {
+    data := map[string]string{}
+    dataNormalizedType, err := resolveType(data, reflect.Map)
+    require.Nil(t, err)
+
+    _, err = newMapMapper(reflect.TypeOf(data), dataNormalizedType, []string{"UserID", "UserId"}, Config{}.resolve())
+    require.NotNil(t, err)
+    assert.Contains(t, err.Error(), "'UserId' collides with 'UserID'")
 }
 
 func testTypedMapMethods(t *testing.T, mapper mapperType, target reflect.Value) {
@@ -33,3 +64,27 @@ func testTypedMapMethods(t *testing.T, mapper mapperType, target reflect.Value)
 
     testMapperItyped(t, mapper, target)
 }
+
+func testUntypedMapMethods(t *testing.T, mapper mapperType, target reflect.Value) {
+    require.NotNil(t, mapper)
+    require.IsType(t, mapperType{}, mapper)
+    require.IsType(t, &MapMapper{}, mapper.mapperTypeI)
+
+    testMapperIuntyped(t, mapper, target)
+}
+
+func TestMapMapperKeys(t *testing.T) {
+    data := map[int]string{}
+    mapper, err := newMapMapper(reflect.TypeOf(data), reflect.TypeOf(data), []string{"10", "20"}, Config{}.resolve())
+    require.Nil(t, err)
+
+    dataVal, err := mapper.create()
+    require.Nil(t, err)
+    require.Nil(t, mapper.set(dataVal, 1, "20", reflect.ValueOf("test string")))
+    assert.Equal(t, map[int]string{20: "test string"}, dataVal.Interface())
+    assert.Equal(t, "test string", mapper.get(dataVal, 1, "20").Interface())
+    assert.Equal(t, "20", (*MapMapper)(&mapper).keyName(reflect.ValueOf(20)))
+
+    _, err = newMapMapper(reflect.TypeOf(data), reflect.TypeOf(data), []string{"int_val"}, Config{}.resolve())
+    assert.NotNil(t, err)
+}
@@ -6,8 +6,15 @@ import (
     "reflect"
 )
 
+// Mapper maps data between two types. Mapper is immutable after 'New': types, links of fields and settings are resolved once,
+// so later changes of package defaults don't affect it. It's safe to use one Mapper from few goroutines concurrently,
+// e.g. for Map, MapInto, GetByName, SetByName or NameByName. Methods that change settings, e.g. WithHeader, return a new Mapper.
+// N.B.: Concurrent access to same target data, e.g. via SetByName, must be synchronized by caller.
 type Mapper struct {
-    types [2]*mapperType
+    types  [2]*mapperType
+    config *Config
+    merge  MergeMode
+    empty  EmptyPolicy
 }
 
 func (m *Mapper) setType(tm *mapperType)(error) {
@@ -24,7 +31,12 @@ func (m *Mapper) setType(tm *mapperType)(error) {
     return err
 }
 
+// getType returns a type of mapper for target. Direction can't be resolved via target for mapper between same types, so 'Forward' or 'Backward' must be used.
 func (m *Mapper) getType(target interface{}) (*mapperType, error) {
+    if m.isSameType() {
+        return nil, errors.New(fmt.Sprintf("Ambiguous direction for mapper between same types '%s'. Use 'Forward' or 'Backward'.", m.types[0].normalizedType))
+    }
+
     if targetType, err := resolveType(target, m.types[0].normalizedType.Kind(), m.types[1].normalizedType.Kind()); err != nil {
         return nil, err
     } else if targetType == m.types[0].normalizedType {
@@ -36,12 +48,44 @@ func (m *Mapper) getType(target interface{}) (*mapperType, error) {
     }
 }
 
+// isSameType returns true if both types of mapper have same normalized type, e.g. []string <-> []string
+func (m *Mapper) isSameType() (bool) {
+    return m.types[0].normalizedType == m.types[1].normalizedType
+}
+
+// checkType returns error if target has no type of t
+func (m *Mapper) checkType(t *mapperType, target interface{}) (error) {
+    if target == nil {
+        return errors.New(fmt.Sprintf("Type mismatch. Expected '%s', but got nil", t.normalizedType))
+    }
+
+    targetType, err := resolveType(target, t.normalizedType.Kind())
+    if err != nil {
+        return err
+    }
+
+    if targetType != t.normalizedType {
+        return errors.New(fmt.Sprintf("Type mismatch. Expected '%s', but got '%s'", t.normalizedType, targetType))
+    }
+
+    return nil
+}
+
+// reverseType returns a type that is opposite to t
+func (m *Mapper) reverseType(t *mapperType) (*mapperType) {
+    if t == m.types[0] {
+        return m.types[1]
+    }
+
+    return m.types[0]
+}
+
 // Set value at target object for field with fieldName or return error if field was not mapped or value could not be converted
 func (m *Mapper) SetByName(target interface{}, fieldName string, value interface{}) (error) {
     if targetType, err := m.getType(target); err == nil {
-        fieldName := NameMapper(fieldName)
+        name := targetType.fieldName(fieldName)
 
-        if field, ok := targetType.fields[fieldName]; !ok {
+        if field, ok := targetType.fields[name]; !ok {
             return unknownFieldName(fieldName)
         } else {
             value := reflect.ValueOf(value)
@@ -53,8 +97,7 @@ func (m *Mapper) SetByName(target interface{}, fieldName string, value interface
             }
 
             target = reflect.Indirect(target)
-            targetType.set(target, field.id, fieldName, value)
-            return nil
+            return targetType.set(target, field.id, name, value)
         }
     } else {
         return err
@@ -64,11 +107,11 @@ func (m *Mapper) SetByName(target interface{}, fieldName string, value interface
 // Get value from target object for field with fieldName or return error if field was not mapped
 func (m *Mapper) GetByName(target interface{}, fieldName string) (interface{}, error) {
     if targetType, err := m.getType(target); err == nil {
-        fieldName := NameMapper(fieldName)
-        if field, ok := targetType.fields[fieldName]; !ok {
+        name := targetType.fieldName(fieldName)
+        if field, ok := targetType.fields[name]; !ok {
             return nil, unknownFieldName(fieldName)
         } else {
-            return targetType.get(reflect.ValueOf(target), field.id, fieldName).Interface(), nil
+            return targetType.get(reflect.ValueOf(target), field.id, name).Interface(), nil
         }
     } else {
         return nil, err
@@ -78,9 +121,9 @@ func (m *Mapper) GetByName(target interface{}, fieldName string) (interface{}, e
 // Return reverse name from object for field with fieldName or return error if field was not mapped
 func (m *Mapper) NameByName(from interface{}, fieldName string) (string, error) {
     if fromType, err := m.getType(from); err == nil {
-        if field, ok := fromType.fields[NameMapper(fieldName)]; ok {
-            if field.reverseId > 0 && len(field.reverseName) > 0 {
-                return field.reverseName, nil
+        if field, ok := fromType.fields[fromType.fieldName(fieldName)]; ok && field.reverseId >= 0 {
+            if reverseField, ok := m.reverseType(fromType).fields[field.reverseName]; ok {
+                return reverseField.name, nil
             }
         }
     }
@@ -88,57 +131,361 @@ func (m *Mapper) NameByName(from interface{}, fieldName string) (string, error)
     return "", unknownFieldName(fieldName)
 }
 
-// Map from object to reverse object or return error if mapping was failed
+// Map from object to reverse object or return error if mapping was failed. Direction is resolved via type of object, so mapper between same types must use 'Forward' or 'Backward'.
 func (m *Mapper) Map(from interface{}) (interface{}, error) {
     if fromType, err := m.getType(from); err != nil {
         return nil, err
     } else {
-        var toType *mapperType
+        result, err := m.mapFrom(fromType, from, false)
+        return result.Value, err
+    }
+}
+
+// MapResult maps from object to reverse object same way as 'Map', but also returns names of fields that were set
+func (m *Mapper) MapResult(from interface{}) (Result, error) {
+    if fromType, err := m.getType(from); err != nil {
+        return Result{}, err
+    } else {
+        return m.mapFrom(fromType, from, true)
+    }
+}
 
-        isToEmpty := true
-        fromVal := reflect.Indirect(reflect.ValueOf(from))
-        if fromType == m.types[0] {
-            toType = m.types[1]
-        } else {
-            toType = m.types[0]
+// Forward maps from object of first type of mapper to a new object of second type. Unlike 'Map' direction is explicit, so it can be used for mapper between same types.
+// Rows of such mapper are mapped via 'MapAll' or 'MapStream' with BatchOptions.Direction.
+func (m *Mapper) Forward(from interface{}) (interface{}, error) {
+    return m.mapDirection(m.types[0], from)
+}
+
+// Backward maps from object of second type of mapper to a new object of first type. See 'Forward' for details.
+func (m *Mapper) Backward(from interface{}) (interface{}, error) {
+    return m.mapDirection(m.types[1], from)
+}
+
+// mapDirection maps from object that must have fromType to a new reverse object
+func (m *Mapper) mapDirection(fromType *mapperType, from interface{}) (interface{}, error) {
+    if err := m.checkType(fromType, from); err != nil {
+        return nil, err
+    }
+
+    result, err := m.mapFrom(fromType, from, false)
+    return result.Value, err
+}
+
+// mapFrom maps from object of fromType to a new reverse object. Result without mapped fields is resolved via empty policy of mapper.
+// Names of fields that were set are collected only if withNames is true.
+func (m *Mapper) mapFrom(fromType *mapperType, from interface{}, withNames bool) (Result, error) {
+    toType := m.reverseType(fromType)
+
+    toVal, err := toType.create()
+    if err != nil {
+        return Result{}, err
+    }
+
+    fromVal, err := indirectFrom(from)
+    if err != nil {
+        return Result{}, err
+    }
+
+    mapped := mappedFields{withNames: withNames}
+    if err := mapValue(fromType, fromVal, toType, &toVal, MergeOverwrite, &mapped); err != nil {
+        return Result{}, err
+    }
+
+    if mapped.count == 0 {
+        switch m.empty {
+        case EmptyError:
+            return Result{}, ErrEmpty
+        case EmptySkip:
+            return Result{}, nil
         }
+    }
+
+    if toType.dataType.Kind() == reflect.Ptr {
+        return Result{Value: toVal.Addr().Interface(), Fields: mapped.names}, nil
+    } else {
+        return Result{Value: toVal.Interface(), Fields: mapped.names}, nil
+    }
+}
+
+// indirectFrom returns a value of object to map from. Returns error if object is a nil pointer, because there is no value to map from.
+func indirectFrom(from interface{}) (reflect.Value, error) {
+    fromVal := reflect.ValueOf(from)
+    if fromVal.Kind() == reflect.Ptr && fromVal.IsNil() {
+        return reflect.Value{}, errors.New(fmt.Sprintf("Can't map from nil pointer of '%s'.", fromVal.Type()))
+    }
+
+    return reflect.Indirect(fromVal), nil
+}
+
+// MapInto maps from object into existing reverse object 'to' or return error if mapping was failed.
+// Fields that are not linked stay untouched and linked fields are merged according to merge mode of mapper. See WithMerge.
+func (m *Mapper) MapInto(from interface{}, to interface{}) (error) {
+    fromType, err := m.getType(from)
+    if err != nil {
+        return err
+    }
+
+    toType, err := m.getType(to)
+    if err != nil {
+        return err
+    }
+
+    if toType != m.reverseType(fromType) {
+        return errors.New(fmt.Sprintf("Type mismatch. Can't map '%s' into same type.", fromType.normalizedType))
+    }
+
+    return m.mapInto(fromType, from, to)
+}
+
+// ForwardInto maps from object of first type of mapper into existing object of second type. See 'MapInto' and 'Forward' for details.
+func (m *Mapper) ForwardInto(from interface{}, to interface{}) (error) {
+    return m.mapIntoDirection(m.types[0], from, to)
+}
+
+// BackwardInto maps from object of second type of mapper into existing object of first type. See 'MapInto' and 'Forward' for details.
+func (m *Mapper) BackwardInto(from interface{}, to interface{}) (error) {
+    return m.mapIntoDirection(m.types[1], from, to)
+}
+
+// mapIntoDirection maps from object that must have fromType into existing reverse object 'to'
+func (m *Mapper) mapIntoDirection(fromType *mapperType, from interface{}, to interface{}) (error) {
+    if err := m.checkType(fromType, from); err != nil {
+        return err
+    }
+
+    if err := m.checkType(m.reverseType(fromType), to); err != nil {
+        return err
+    }
+
+    return m.mapInto(fromType, from, to)
+}
+
+// mapInto maps from object of fromType into existing reverse object 'to'
+func (m *Mapper) mapInto(fromType *mapperType, from interface{}, to interface{}) (error) {
+    toType := m.reverseType(fromType)
+
+    fromVal, err := indirectFrom(from)
+    if err != nil {
+        return err
+    }
 
-        toVal, err := toType.create()
-        if err != nil {
-            return nil, err
+    //check if 'to' is pointer to actual value
+    toVal := reflect.ValueOf(to)
+    if toVal.Kind() != reflect.Ptr || toVal.IsNil() {
+        return errors.New("You can't directly mutate the 'to'. Use a pointer to 'to'.")
+    }
+
+    toVal = toVal.Elem()
+
+    //nil map or slice that is shorter than required gets a new storage with existing values
+    if toVal.Kind() == reflect.Map && toVal.IsNil() {
+        toVal.Set(reflect.MakeMap(toVal.Type()))
+    } else if toVal.Kind() == reflect.Slice {
+        if created, err := toType.create(); err == nil && created.Len() > toVal.Len() {
+            reflect.Copy(created, toVal)
+            toVal.Set(created)
         }
+    }
 
-        for fieldName, field := range toType.fields {
-            if field.reverseId < 0 || field.omit {
-                continue
-            }
+    mapped := mappedFields{}
+    if err := mapValue(fromType, fromVal, toType, &toVal, m.merge, &mapped); err != nil {
+        return err
+    }
 
-            fromFieldVal := fromType.get(fromVal, field.reverseId, field.reverseName)
-            if !fromFieldVal.IsValid() {
-                continue
-            }
+    if mapped.count == 0 && m.empty == EmptyError {
+        return ErrEmpty
+    }
+
+    return nil
+}
+
+// WithMerge returns a copy of mapper that merges values at 'MapInto' with mode
+func (m *Mapper) WithMerge(mode MergeMode) (*Mapper) {
+    merged := *m
+    merged.merge = mode
+    return &merged
+}
+
+// WithEmpty returns a copy of mapper that returns results without mapped fields according to policy
+func (m *Mapper) WithEmpty(policy EmptyPolicy) (*Mapper) {
+    c := *m
+    c.empty = policy
+    return &c
+}
+
+// mappedFields holds a number of fields that were set and names of them if names are required
+type mappedFields struct {
+    count     int
+    names     []string
+    withNames bool
+}
 
-            toFieldVal := toType.get(toVal, field.id, fieldName)
+// add registers a field with name that was set
+func (m *mappedFields) add(name string) {
+    m.count++
 
-            if val, err := field.convert(fromFieldVal, toFieldVal.Type()); err != nil {
-                return nil, errors.New(fmt.Sprintf("Could not convert '%s'. %s", fieldName, err.Error()))
-            } else {
-                if val.IsValid() {
-                    isToEmpty = false
-                    toFieldVal.Set(val)
+    if m.withNames {
+        m.names = append(m.names, name)
+    }
+}
+
+// addAll registers fields with names that were set
+func (m *mappedFields) addAll(names []string) {
+    m.count += len(names)
+
+    if m.withNames {
+        m.names = append(m.names, names...)
+    }
+}
+
+// mapValue maps from value of fromType to value of toType with merge mode via compiled plan of toType and registers fields that were set at mapped.
+func mapValue(fromType *mapperType, fromVal reflect.Value, toType *mapperType, toVal *reflect.Value, mode MergeMode, mapped *mappedFields) (error) {
+    plan := toType.plan
+
+    //keys of map are indexed once per map
+    keys := newMapKeys(fromType, fromVal)
+
+    for i := range plan.steps {
+        step := &plan.steps[i]
+
+        fromFieldVal := step.get(fromType, fromVal, keys)
+        if !fromFieldVal.IsValid() {
+            continue
+        }
+
+        //fast path: value is converted directly into destination
+        if step.isDirect && mode == MergeOverwrite {
+            if toFieldVal := toType.get(*toVal, step.field.id, step.name); toFieldVal.IsValid() {
+                if step.isSame {
+                    toFieldVal.Set(fromFieldVal)
+                    mapped.add(step.field.name)
+                } else if isSet, err := convertTo(fromFieldVal, toFieldVal); err != nil {
+                    return errors.New(fmt.Sprintf("Could not convert '%s'. %s", step.field.name, err.Error()))
+                } else if isSet {
+                    mapped.add(step.field.name)
                 }
+
+                continue
             }
         }
 
-        if isToEmpty {
-            return nil, nil
+        valueType := step.valueType
+        if valueType == nil {
+            valueType = toType.get(*toVal, step.field.id, step.name).Type()
         }
 
-        if toType.dataType.Kind() == reflect.Ptr {
-            return toVal.Addr().Interface(), nil
+        if val, err := step.field.convert(fromFieldVal, valueType); err != nil {
+            return errors.New(fmt.Sprintf("Could not convert '%s'. %s", step.field.name, err.Error()))
+        } else if isSet, err := mergeValue(toType, *toVal, step.field.id, step.name, fromFieldVal, val, mode); err != nil {
+            return err
+        } else if isSet {
+            mapped.add(step.field.name)
+        }
+    }
+
+    if plan.combined {
+        if combinedMapped, err := mapCombined(fromType, fromVal, keys, toType, *toVal, mode); err != nil {
+            return err
         } else {
-            return toVal.Interface(), nil
+            mapped.addAll(combinedMapped)
         }
     }
+
+    if plan.rest {
+        if restMapped, err := mapRest(fromType, fromVal, toType, toVal, mode); err != nil {
+            return err
+        } else {
+            mapped.addAll(restMapped)
+        }
+    }
+
+    if mapped.withNames {
+        sortFieldNames(toType, mapped.names)
+    }
+
+    return nil
 }
 
+// WithHeader returns a copy of mapper that is bound to header, i.e. fields of named slice are mapped by indexes of same names at header.
+// Order of columns at header doesn't matter, unknown columns are ignored and missing columns are not mapped.
+// Returns error if column for field with 'required' option is missing at header or if column of linked field is repeated.
+func (m *Mapper) WithHeader(header []string) (*Mapper, error) {
+    var sliceType *mapperType
+
+    for _, t := range m.types {
+        if sliceMapper, isSlice := t.mapperTypeI.(*SliceMapper); isSlice && sliceMapper.isNamed() {
+            sliceType = t
+        }
+    }
+
+    if sliceType == nil {
+        return nil, errors.New("Only mapper with named slice can be bound to header.")
+    }
+
+    var placeholder interface{}
+    if sliceType.placeholder.IsValid() {
+        placeholder = sliceType.placeholder.Interface()
+    }
+
+    headerType, err := newSliceMapper(sliceType.dataType, sliceType.normalizedType, SliceOptions{Width: len(header), Placeholder: placeholder}, m.config)
+    if err != nil {
+        return nil, err
+    }
+
+    //only columns of mapped fields must be unique, other columns can be blank or repeated, e.g. extra columns of CSV file
+    for i, column := range header {
+        fieldName := headerType.fieldName(column)
+        if registered, ok := headerType.fields[fieldName]; ok {
+            if field, ok := sliceType.fields[fieldName]; ok && (field.reverseId >= 0 || field.combined != nil) {
+                return nil, errors.New(fmt.Sprintf("Column '%s' collides with '%s' at header. Both are normalized to '%s'.", column, registered.name, fieldName))
+            }
+
+            continue
+        }
+
+        if len(fieldName) > 0 {
+            headerType.fields[fieldName] = &mapperField{id: i, name: column, convert: m.config.ValueConverter, reverseId: -1}
+        }
+    }
+
+    bound := &Mapper{config: m.config, merge: m.merge, empty: m.empty}
+    for i, t := range m.types {
+        if t == sliceType {
+            bound.types[i] = &headerType
+        } else {
+            bound.types[i] = t.clone()
+        }
+    }
+
+    reverseType := bound.reverseType(&headerType)
+    for fieldName, field := range sliceType.fields {
+        if field.reverseId < 0 {
+            continue
+        }
+
+        reverseField, ok := reverseType.fields[field.reverseName]
+        if !ok {
+            continue
+        }
+
+        if headerField, ok := headerType.fields[fieldName]; ok {
+            linked := *field
+            linked.id = headerField.id
+            linked.name = headerField.name
+            headerType.fields[fieldName] = &linked
+
+            //combined field is linked by names
+            if field.combined == nil {
+                reverseField.reverseId = linked.id
+            }
+        } else if field.required || reverseField.required {
+            return nil, errors.New(fmt.Sprintf("Required column '%s' is missing at header.", field.name))
+        } else if field.combined == nil {
+            reverseField.reverseId = -1
+            reverseField.reverseName = ""
+        }
+    }
+
+    bound.compile()
+    return bound, nil
+}
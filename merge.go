@@ -0,0 +1,68 @@
+package remapper
+
+import (
+    "reflect"
+)
+
+// MergeMode is a way to merge mapped values into existing data at 'MapInto'
+type MergeMode int
+
+const (
+    // MergeOverwrite overwrites linked fields of data with any mapped values. Default.
+    MergeOverwrite MergeMode = iota
+
+    // MergeNonEmpty overwrites linked fields of data only with non-empty source values, e.g. 0, "" or false are skipped, but "0" or "false" are set
+    MergeNonEmpty
+
+    // MergeZero fills only linked fields of data that have zero values
+    MergeZero
+)
+
+// mergeValue sets a converted value to field of t with id and name if merge mode allows it. Returns true if value was set.
+// Emptiness is checked via source value as it was before conversion, e.g. "0" is not empty, even if it's converted to zero.
+func mergeValue(t *mapperType, to reflect.Value, id int, name string, source reflect.Value, value reflect.Value, mode MergeMode) (bool, error) {
+    if !value.IsValid() {
+        return false, nil
+    }
+
+    var current reflect.Value
+    if mode == MergeZero {
+        current = t.get(to, id, name)
+    }
+
+    if !canMerge(current, source, mode) {
+        return false, nil
+    }
+
+    return true, t.set(to, id, name, value)
+}
+
+// canMerge returns true if merge mode allows to replace current value with value of source. Invalid current value is a missing one, e.g. key of map.
+func canMerge(current reflect.Value, source reflect.Value, mode MergeMode) (bool) {
+    switch mode {
+    case MergeNonEmpty:
+        return source.IsValid() && !isEmptyValue(source)
+    case MergeZero:
+        return !current.IsValid() || isEmptyValue(current)
+    }
+
+    return true
+}
+
+// isEmptyValue returns true if value is zero value of own type. Value of interface is checked by value it holds.
+func isEmptyValue(value reflect.Value) (bool) {
+    if value.Kind() == reflect.Interface {
+        if value.IsNil() {
+            return true
+        }
+
+        value = value.Elem()
+    }
+
+    switch value.Kind() {
+    case reflect.Slice, reflect.Map:
+        return value.Len() == 0
+    }
+
+    return value.IsZero()
+}
@@ -0,0 +1,63 @@
+package remapper
+
+import (
+    "testing"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestMapInto(t *testing.T) {
+    mapper, err := New(TestStructNamed{}, Slice([]string{}, arrayFieldNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    //slice -> struct
+    s := TestStructNamed{IntVal: 10, StrVal: "old string"}
+    require.Nil(t, mapper.MapInto(arrayTyped, &s))
+    assert.Equal(t, mappedNamedStruct, s)
+
+    //struct -> existing slice, not linked positions are untouched
+    arr := []string{"f1", "", "", "f2", "", "", "", "f3"}
+    require.Nil(t, mapper.MapInto(mappedNamedStruct, &arr))
+    assert.Equal(t, []string{"f1", "-1", "1", "f2", "test string", "1.2345", "true", "f3"}, arr)
+
+    //struct -> short slice
+    arr = []string{"f1"}
+    require.Nil(t, mapper.MapInto(&mappedNamedStruct, &arr))
+    assert.Equal(t, []string{"f1", "-1", "1", "", "test string", "1.2345", "true", ""}, arr)
+
+    //only non-empty values, "0" and "false" are not empty sources, even if converted to zero values
+    s = TestStructNamed{IntVal: 10, UintVal: 20, StrVal: "old string", FloatVal: 1.5, BoolVal: true}
+    require.Nil(t, mapper.WithMerge(MergeNonEmpty).MapInto([]string{"", "0", "2", "", "", "", "false", ""}, &s))
+    assert.Equal(t, TestStructNamed{0, 2, "old string", 1.5, false}, s)
+
+    s = TestStructNamed{IntVal: 10, UintVal: 20, StrVal: "old string", FloatVal: 1.5, BoolVal: true}
+    require.Nil(t, mapper.WithMerge(MergeNonEmpty).MapInto([]string{"", "", "", "", "", "", "", ""}, &s))
+    assert.Equal(t, TestStructNamed{10, 20, "old string", 1.5, true}, s)
+
+    //overwrite all values
+    require.Nil(t, mapper.WithMerge(MergeOverwrite).MapInto([]string{"", "0", "2", "", "", "", "false", ""}, &s))
+    assert.Equal(t, TestStructNamed{0, 2, "old string", 1.5, false}, s)
+
+    //only zero values
+    s = TestStructNamed{IntVal: 10, StrVal: "old string"}
+    require.Nil(t, mapper.WithMerge(MergeZero).MapInto(arrayTyped, &s))
+    assert.Equal(t, TestStructNamed{10, 1, "old string", 1.2345, true}, s)
+
+    //struct -> existing map
+    mapper, err = New(TestStructNamed{}, Map(map[string]string{}, arrayFieldNames))
+    require.Nil(t, err)
+
+    m := map[string]string{"_f1": "f1", "int_val": "10"}
+    require.Nil(t, mapper.WithMerge(MergeZero).MapInto(mappedNamedStruct, &m))
+    assert.Equal(t, map[string]string{"_f1": "f1", "int_val": "10", "uint_val": "1", "str_val": "test string", "float_val": "1.2345", "bool_val": "true"}, m)
+
+    var nilMap map[string]string
+    require.Nil(t, mapper.MapInto(mappedNamedStruct, &nilMap))
+    assert.Equal(t, "test string", nilMap["str_val"])
+
+    //errors
+    assert.NotNil(t, mapper.MapInto(mappedNamedStruct, m))
+    assert.NotNil(t, mapper.MapInto(mappedNamedStruct, &TestStructNamed{}))
+    assert.NotNil(t, mapper.MapInto(mappedNamedStruct, &[]string{}))
+}
@@ -0,0 +1,133 @@
+package remapper
+
+import (
+    "errors"
+    "fmt"
+    "reflect"
+)
+
+// Pair is a type-safe mapper between data of type A and data of type B with explicit direction of mapping
+type Pair[A any, B any] struct {
+    mapper *Mapper
+}
+
+// NewPair creates a new Pair for mapping between A and B.
+//
+// - a and b are settings of types as at 'New', e.g. Slice([]string{}, names), or nil to use A and B as is
+// - mapping is optional and same as at 'New'
+//
+// Settings of types must have exactly types A and B, e.g. pointer to struct for *MyStruct.
+func NewPair[A any, B any](a interface{}, b interface{}, mapping ...interface{}) (*Pair[A, B], error) {
+    if a == nil {
+        a = newPairData[A]()
+    }
+
+    if b == nil {
+        b = newPairData[B]()
+    }
+
+    mapper, err := New(append([]interface{}{a, b}, mapping...)...)
+    if err != nil {
+        return nil, err
+    }
+
+    if t := reflect.TypeOf((*A)(nil)).Elem(); mapper.types[0].dataType != t {
+        return nil, errors.New(fmt.Sprintf("Type mismatch. Expected '%s', but got '%s'", t, mapper.types[0].dataType))
+    }
+
+    if t := reflect.TypeOf((*B)(nil)).Elem(); mapper.types[1].dataType != t {
+        return nil, errors.New(fmt.Sprintf("Type mismatch. Expected '%s', but got '%s'", t, mapper.types[1].dataType))
+    }
+
+    return &Pair[A, B]{mapper: mapper}, nil
+}
+
+// newPairData returns a data of type T to resolve a type mapper. Pointer points to a new value, so type of value can be resolved.
+func newPairData[T any]() (interface{}) {
+    t := reflect.TypeOf((*T)(nil)).Elem()
+    if t.Kind() == reflect.Ptr {
+        return reflect.New(t.Elem()).Interface()
+    }
+
+    return reflect.Zero(t).Interface()
+}
+
+// Mapper returns an underlying mapper
+func (p *Pair[A, B]) Mapper() (*Mapper) {
+    return p.mapper
+}
+
+// Forward maps from A to a new B. Returns zero value of B if nothing was mapped or error if A is a nil pointer.
+func (p *Pair[A, B]) Forward(from A) (B, error) {
+    var to B
+
+    v, err := p.mapper.mapFrom(p.mapper.types[0], from, false)
+    if err != nil || v.Value == nil {
+        return to, err
+    }
+
+    return v.Value.(B), nil
+}
+
+// Backward maps from B to a new A. Returns zero value of A if nothing was mapped or error if B is a nil pointer.
+func (p *Pair[A, B]) Backward(from B) (A, error) {
+    var to A
+
+    v, err := p.mapper.mapFrom(p.mapper.types[1], from, false)
+    if err != nil || v.Value == nil {
+        return to, err
+    }
+
+    return v.Value.(A), nil
+}
+
+// ForwardInto maps from A into existing B. See 'MapInto' for details.
+func (p *Pair[A, B]) ForwardInto(from A, to *B) (error) {
+    return p.mapper.mapInto(p.mapper.types[0], from, pairTarget(to))
+}
+
+// BackwardInto maps from B into existing A. See 'MapInto' for details.
+func (p *Pair[A, B]) BackwardInto(from B, to *A) (error) {
+    return p.mapper.mapInto(p.mapper.types[1], from, pairTarget(to))
+}
+
+// ForwardAll maps each A to B. Returns error with index of A that could not be mapped.
+func (p *Pair[A, B]) ForwardAll(from []A) ([]B, error) {
+    to := make([]B, len(from))
+
+    for i := range from {
+        var err error
+        if to[i], err = p.Forward(from[i]); err != nil {
+            return nil, errors.New(fmt.Sprintf("Could not map item %d. %s", i, err.Error()))
+        }
+    }
+
+    return to, nil
+}
+
+// BackwardAll maps each B to A. Returns error with index of B that could not be mapped.
+func (p *Pair[A, B]) BackwardAll(from []B) ([]A, error) {
+    to := make([]A, len(from))
+
+    for i := range from {
+        var err error
+        if to[i], err = p.Backward(from[i]); err != nil {
+            return nil, errors.New(fmt.Sprintf("Could not map item %d. %s", i, err.Error()))
+        }
+    }
+
+    return to, nil
+}
+
+// pairTarget returns a pointer to actual data for pointer to T, e.g. *MyStruct for **MyStruct
+func pairTarget[T any](to *T) (interface{}) {
+    if v := reflect.ValueOf(to).Elem(); v.Kind() == reflect.Ptr {
+        if v.IsNil() {
+            v.Set(reflect.New(v.Type().Elem()))
+        }
+
+        return v.Interface()
+    }
+
+    return to
+}
@@ -0,0 +1,77 @@
+package remapper
+
+import (
+    "testing"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestPair(t *testing.T) {
+    pair, err := NewPair[*TestStructNamed, []string](nil, Slice([]string{}, arrayFieldNames))
+    require.Nil(t, err)
+    require.NotNil(t, pair)
+
+    arr, err := pair.Forward(&mappedNamedStruct)
+    require.Nil(t, err)
+    assert.Equal(t, []string{"", "-1", "1", "", "test string", "1.2345", "true", ""}, arr)
+
+    s, err := pair.Backward(arrayTyped)
+    require.Nil(t, err)
+    assert.Equal(t, &mappedNamedStruct, s)
+
+    //nothing was mapped
+    s, err = pair.Backward(make([]string, 8))
+    require.Nil(t, err)
+    assert.Nil(t, s)
+
+    //nil pointer has no value to map from
+    arr, err = pair.Forward(nil)
+    assert.NotNil(t, err)
+    assert.Nil(t, arr)
+
+    assert.NotNil(t, pair.ForwardInto(nil, &arr))
+
+    all, err := pair.ForwardAll([]*TestStructNamed{&mappedNamedStruct, nil})
+    assert.Nil(t, all)
+    require.NotNil(t, err)
+    assert.Contains(t, err.Error(), "item 1")
+
+    _, err = pair.Mapper().Map((*TestStructNamed)(nil))
+    assert.NotNil(t, err)
+
+    //into existing data
+    arr = []string{"f1", "", "", "f2", "", "", "", "f3"}
+    require.Nil(t, pair.ForwardInto(&mappedNamedStruct, &arr))
+    assert.Equal(t, []string{"f1", "-1", "1", "f2", "test string", "1.2345", "true", "f3"}, arr)
+
+    var sp *TestStructNamed
+    require.Nil(t, pair.BackwardInto(arrayTyped, &sp))
+    assert.Equal(t, &mappedNamedStruct, sp)
+
+    //all items
+    all, err = pair.ForwardAll([]*TestStructNamed{&mappedNamedStruct, {StrVal: "test"}})
+    require.Nil(t, err)
+    require.Len(t, all, 2)
+    assert.Equal(t, "test string", all[0][4])
+    assert.Equal(t, "test", all[1][4])
+
+    structs, err := pair.BackwardAll([][]string{arrayTyped, {"", "invalid"}})
+    assert.Nil(t, structs)
+    require.NotNil(t, err)
+    assert.Contains(t, err.Error(), "item 1")
+
+    //types of settings must match
+    _, err = NewPair[TestStructNamed, []string](&TestStructNamed{}, Slice([]string{}, arrayFieldNames))
+    assert.NotNil(t, err)
+
+    _, err = NewPair[TestStructNamed, []interface{}](nil, Slice([]string{}, arrayFieldNames))
+    assert.NotNil(t, err)
+
+    //types as is
+    mapPair, err := NewPair[TestStructIndexed, map[int]string](nil, nil)
+    require.Nil(t, err)
+
+    m, err := mapPair.Forward(mappedIndexedStruct)
+    require.Nil(t, err)
+    assert.Equal(t, "test string", m[4])
+}
@@ -0,0 +1,150 @@
+package remapper
+
+import (
+    "reflect"
+    "sort"
+)
+
+// mappingPlan is an ordered list of steps to map into type from linked type. Plan is compiled once at 'New'.
+type mappingPlan struct {
+    steps    []planStep
+    combined bool //Holds flag that any of types has combined fields
+    rest     bool //Holds flag that any of types has field to collect not linked data
+
+    combine []combinedStep //Holds combined fields of type to combine from linked fields of linked type
+    split   []combinedStep //Holds combined fields of linked type to split into linked fields of type
+
+    restName      string          //Holds name of field of type that collects not linked data
+    restField     *mapperField    //Holds field of type that collects not linked data or nil if there is no such field
+    linkedIndexes map[int]bool    //Holds indexes of linked type that are linked to fields of type
+    linkedNames   map[string]bool //Holds names of linked type that are linked to fields of type
+    positions     map[int]string  //Holds names of fields of linked type by indexes
+}
+
+// planStep is a step to map a value of one linked field
+type planStep struct {
+    field     *mapperField //Holds destination field
+    name      string       //Holds name of destination field
+    fromField *mapperField //Holds source field
+    fromName  string       //Holds name of source field
+    valueType reflect.Type //Holds type of destination value
+    isDirect  bool         //Holds flag that value can be converted directly into destination, i.e. default converter is used and destination is addressable
+    isSame    bool         //Holds flag that source and destination have same type, so value can be set as is
+}
+
+// combinedStep is a step to map values between a combined field and its linked fields
+type combinedStep struct {
+    field  *mapperField   //Holds combined field
+    name   string         //Holds name of combined field
+    linked []*mapperField //Holds linked fields in order of combining, nil for names that are not registered
+}
+
+// compile builds a plan to map into t from linkedType. Steps are ordered by fields of t.
+func (t *mapperType) compile(linkedType *mapperType) {
+    plan := &mappingPlan{}
+
+    to, toErr := t.create()
+    from, fromErr := linkedType.create()
+    _, isMap := t.mapperTypeI.(*MapMapper)
+
+    for fieldName, field := range t.fields {
+        if field.reverseId < 0 || field.omit || field.combined != nil {
+            continue
+        }
+
+        step := planStep{
+            field:     field,
+            name:      fieldName,
+            fromField: linkedType.fields[field.reverseName],
+            fromName:  field.reverseName,
+        }
+
+        if toErr == nil {
+            step.valueType = t.get(to, field.id, fieldName).Type()
+            step.isDirect = !isMap && step.valueType.Kind() != reflect.Interface && isDefaultConverter(field.convert)
+
+            if fromErr == nil {
+                if fromValue := linkedType.get(from, field.reverseId, field.reverseName); fromValue.IsValid() {
+                    step.isSame = step.isDirect && fromValue.Type() == step.valueType && step.valueType.Kind() != reflect.String
+                }
+            }
+        }
+
+        plan.steps = append(plan.steps, step)
+    }
+
+    sort.Slice(plan.steps, func(i, j int) bool {
+        return plan.steps[i].field.id < plan.steps[j].field.id
+    })
+
+    for _, fields := range []map[string]*mapperField{t.fields, linkedType.fields} {
+        for _, field := range fields {
+            plan.combined = plan.combined || field.combined != nil
+            plan.rest = plan.rest || field.rest
+        }
+    }
+
+    if plan.combined {
+        plan.combine = compileCombined(t, linkedType)
+        plan.split = compileCombined(linkedType, t)
+    }
+
+    if plan.rest {
+        plan.restName, plan.restField = getRestField(t)
+        plan.linkedIndexes, plan.linkedNames = getLinkedKeys(t, linkedType)
+        plan.positions = make(map[int]string, len(linkedType.fields))
+
+        for _, field := range linkedType.fields {
+            plan.positions[field.id] = field.name
+        }
+    }
+
+    t.plan = plan
+}
+
+// compileCombined returns steps for combined fields of t that are linked to fields of linkedType. Steps are ordered by fields of t.
+func compileCombined(t *mapperType, linkedType *mapperType) ([]combinedStep) {
+    var steps []combinedStep
+
+    for fieldName, field := range t.fields {
+        if field.combined == nil || field.reverseId >= 0 || field.omit {
+            continue
+        }
+
+        step := combinedStep{field: field, name: fieldName, linked: make([]*mapperField, len(field.combined.names))}
+        for i, name := range field.combined.names {
+            step.linked[i] = linkedType.fields[name]
+        }
+
+        steps = append(steps, step)
+    }
+
+    sort.Slice(steps, func(i, j int) bool {
+        return steps[i].field.id < steps[j].field.id
+    })
+
+    return steps
+}
+
+// get returns a value of source field. Keys of map with other case than names are resolved via keys.
+func (step *planStep) get(fromType *mapperType, from reflect.Value, keys *mapKeys) (reflect.Value) {
+    //key of map is resolved already
+    if step.fromField != nil && step.fromField.key.IsValid() {
+        if v := from.MapIndex(step.fromField.key); v.IsValid() {
+            return v
+        }
+    }
+
+    return keys.get(fromType, from, step.field.reverseId, step.fromName)
+}
+
+// isDefaultConverter returns true if convert is Convert, i.e. value can be converted directly into destination
+func isDefaultConverter(convert ConvertFunc) (bool) {
+    return isSameConverter(convert, Convert)
+}
+
+// compile builds plans for both types of mapper
+func (m *Mapper) compile() {
+    m.types[0].compile(m.types[1])
+    m.types[1].compile(m.types[0])
+}
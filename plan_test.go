@@ -0,0 +1,61 @@
+package remapper
+
+import (
+    "testing"
+    "reflect"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestMappingPlan(t *testing.T) {
+    mapper, err := New(TestStructNamed{}, Slice([]interface{}{}, arrayFieldNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    //steps are ordered by fields of destination
+    structType, sliceType := mapper.types[0], mapper.types[1]
+    require.Len(t, structType.plan.steps, 5)
+    for i, step := range structType.plan.steps {
+        assert.Equal(t, i, step.field.id)
+        assert.True(t, step.isDirect)
+        assert.False(t, step.isSame)
+    }
+
+    require.Len(t, sliceType.plan.steps, 5)
+    assert.Equal(t, []int{1, 2, 4, 5, 6}, []int{
+        sliceType.plan.steps[0].field.id,
+        sliceType.plan.steps[1].field.id,
+        sliceType.plan.steps[2].field.id,
+        sliceType.plan.steps[3].field.id,
+        sliceType.plan.steps[4].field.id,
+    })
+
+    //untyped destination can't be converted directly
+    assert.False(t, sliceType.plan.steps[0].isDirect)
+
+    //same types
+    mapper, err = New(TestStructNamed{}, Map(map[string]int{}, []string{"int_val"}), map[string]string{"IntVal": "int_val"})
+    require.Nil(t, err)
+    assert.True(t, mapper.types[0].plan.steps[0].isSame)
+
+    //custom converter is used as is
+    converter := func(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
+        return Convert(from, toType)
+    }
+
+    custom, err := New(TestStructNamed{}, Slice([]string{}, arrayFieldNames), Config{ValueConverter: converter})
+    require.Nil(t, err)
+    assert.False(t, custom.types[0].plan.steps[0].isDirect)
+
+    mapper, err = New(TestStructNamed{}, Slice([]string{}, arrayFieldNames))
+    require.Nil(t, err)
+
+    for _, row := range [][]string{arrayTyped, {"", " -1 ", "", "", " ", "1,5", "1", ""}, make([]string, 8)} {
+        expected, err := custom.Map(row)
+        require.Nil(t, err)
+
+        s, err := mapper.Map(row)
+        require.Nil(t, err)
+        assert.Equal(t, expected, s)
+    }
+}
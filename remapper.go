@@ -11,13 +11,28 @@ import (
     "fmt"
     "errors"
     "reflect"
+
+    "github.com/plandem/remapper/internal/tags"
 )
 
 type option func(m *Mapper)(error)
 
 // Creates a new Mapper object that can be used for mapping from one type of data to another. E.g.: slice -> struct, struct -> slice, struct -> map, ...
+// Config can be provided at any position to use own settings instead of package defaults, e.g.: New(&MyStruct{}, []string{}, Config{TagName: "csv"})
 func New(args ...interface{})(*Mapper, error) {
-    m := &Mapper{}
+    config := Config{}
+    typeArgs := args[:0:0]
+
+    for _, arg := range args {
+        if c, ok := arg.(Config); ok {
+            config = c
+        } else {
+            typeArgs = append(typeArgs, arg)
+        }
+    }
+
+    args = typeArgs
+    m := &Mapper{config: config.resolve(), merge: config.Merge, empty: config.Empty}
     options := []option{}
 
     //resolve type1 mapper
@@ -62,18 +77,21 @@ func New(args ...interface{})(*Mapper, error) {
         }
     }
 
+    m.compile()
     return m, nil
 }
 
 //Slice returns option to setup slice mapper
 func Slice(t interface{}, options interface{})(option) {
     return func(m *Mapper)(error) {
-        normalizedType, err := resolveType(t, reflect.Slice)
+        normalizedType, err := resolveType(t, reflect.Slice, reflect.Array)
 
         if err == nil {
             sliceType := reflect.TypeOf(t)
-            sliceMapper := newSliceMapper(sliceType, normalizedType, options)
-            err = m.setType(&sliceMapper)
+            var sliceMapper mapperType
+            if sliceMapper, err = newSliceMapper(sliceType, normalizedType, options, m.config); err == nil {
+                err = m.setType(&sliceMapper)
+            }
         }
 
         return err
@@ -81,21 +99,16 @@ func Slice(t interface{}, options interface{})(option) {
 }
 
 //Map returns option to setup map mapper
-func Map(t interface{}, names []string)(option) {
+func Map(t interface{}, options interface{})(option) {
     return func(m *Mapper)(error) {
         normalizedType, err := resolveType(t, reflect.Map)
 
         if err == nil {
             mapType := reflect.TypeOf(t)
-            if len(names) > 0 {
-                if mapType.Elem().Kind() == reflect.Interface {
-                    err = errors.New("You map to/from typed map only.")
-                } else {
-                    mapMapper := newMapMapper(mapType, normalizedType, names)
-                    err = m.setType(&mapMapper)
-                }
-            } else {
-                err = errors.New("You must provide names for mapping to/from map.")
+
+            var mapMapper mapperType
+            if mapMapper, err = newMapMapper(mapType, normalizedType, options, m.config); err == nil {
+                err = m.setType(&mapMapper)
             }
         }
 
@@ -110,8 +123,10 @@ func Struct(t interface{})(option) {
 
         if err == nil {
             structType := reflect.TypeOf(t)
-            structMapper := newStructMapper(structType, normalizedType)
-            err = m.setType(&structMapper)
+            var structMapper mapperType
+            if structMapper, err = newStructMapper(structType, normalizedType, m.config); err == nil {
+                err = m.setType(&structMapper)
+            }
         }
 
         return err
@@ -120,7 +135,7 @@ func Struct(t interface{})(option) {
 
 //resolveTypeMapper returns option to setup mapper for type
 func resolveTypeMapper(t interface{})(option, error) {
-    normalizedType, err := resolveType(t, reflect.Struct, reflect.Slice, reflect.Map, reflect.Func)
+    normalizedType, err := resolveType(t, reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Func)
     if err != nil {
         return nil, err
     }
@@ -129,13 +144,13 @@ func resolveTypeMapper(t interface{})(option, error) {
     invalidFuncOption := errors.New(fmt.Sprintf("Unknown option. It must be type of %+s", reflect.TypeOf(invalidFuncType)))
 
     switch normalizedType.Kind() {
-    case reflect.Slice:
+    case reflect.Slice, reflect.Array:
         //TODO: get names from 't'?
         return Slice(t, nil), nil
     case reflect.Struct:
         return Struct(t), nil
     case reflect.Map:
-        //TODO: get names from 't'?
+        //names will be derived from struct via tags
         return Map(t, nil), nil
     case reflect.Func:
         if typeOption, ok := t.(option); !ok {
@@ -150,8 +165,9 @@ func resolveTypeMapper(t interface{})(option, error) {
 
 //resolveTypeMapping returns option to setup mapping between types
 func resolveTypeMapping(m interface{})(option, error) {
+    //tag name of mapper will be used
     if m == nil {
-        m = TagName
+        m = ""
     }
 
     normalizedMapping, err := resolveType(m, reflect.Map, reflect.String)
@@ -173,48 +189,132 @@ func resolveTypeMapping(m interface{})(option, error) {
 //tagMapping returns option to setup mapping via tags of struct
 func tagMapping(tag string)(option) {
     return func(m *Mapper) (error) {
-        var mapping map[string]string
-        var err error
+        var structType, linkedType *mapperType
+
+        if len(tag) == 0 {
+            tag = m.config.TagName
+        }
 
         if m.types[0].normalizedType.Kind() == reflect.Struct {
-            mapping, err = getTagMapping(m.types[0], tag, false)
+            structType, linkedType = m.types[0], m.types[1]
         } else if m.types[1].normalizedType.Kind() == reflect.Struct{
-            mapping, err = getTagMapping(m.types[1], tag, true)
+            structType, linkedType = m.types[1], m.types[0]
         } else {
-            err = errors.New("Only struct supports mapping via tags. You must provide manual mapping via 'mapping' argument for other types.")
+            return errors.New("Only struct supports mapping via tags. You must provide manual mapping via 'mapping' argument for other types.")
         }
 
+        //misspelled profile would silently fall back to default sections
+        if len(m.config.Profile) > 0 && !hasProfile(structType.normalizedType, tag, m.config.Profile, map[reflect.Type]bool{}) {
+            return errors.New(fmt.Sprintf("There is no section for profile '%s' at tags '%s' of %v.", m.config.Profile, tag, structType.normalizedType))
+        }
+
+        mapping, err := getTagMapping(structType, tag)
         if err != nil {
             return err
         }
 
-        return fieldMapping(mapping)(m)
+        //names of map are derived from struct if names were not provided
+        if mapMapper, isMap := linkedType.mapperTypeI.(*MapMapper); isMap && len(linkedType.fields) == 0 {
+            if mapping, err = mapMapper.deriveNames(structType, tag, mapping); err != nil {
+                return err
+            }
+        }
+
+        //fields are linked in both directions, so mapping from struct's side can be used for any order of types
+        return resolveMapping(structType, linkedType, mapping)
     }
 }
 
 //fieldMapping returns option to setup mapping via map with names or indexes
 func fieldMapping(mapping interface{})(option) {
     return func(m *Mapper) (error) {
+        for _, t := range m.types {
+            if _, isMap := t.mapperTypeI.(*MapMapper); isMap && len(t.fields) == 0 {
+                return errors.New("You must provide names for mapping to/from map.")
+            }
+        }
+
         return resolveMapping(m.types[0], m.types[1], mapping)
     }
 }
 
+// hasProfile returns true if any field of struct t or of nested structs has a section for profile at tag tagName
+func hasProfile(t reflect.Type, tagName string, profile string, visited map[reflect.Type]bool) (bool) {
+    if visited[t] {
+        return false
+    }
+
+    visited[t] = true
+    for i, i_max := 0, t.NumField(); i < i_max; i++ {
+        f := t.Field(i)
+        if tags.HasProfile(f.Tag.Get(tagName), profile) {
+            return true
+        }
+
+        fieldType := f.Type
+        if fieldType.Kind() == reflect.Ptr {
+            fieldType = fieldType.Elem()
+        }
+
+        if fieldType.Kind() == reflect.Struct && hasProfile(fieldType, tagName, profile, visited) {
+            return true
+        }
+    }
+
+    return false
+}
+
+// fieldTag returns a field mapping of field f from tag tagName for profile.
+// Returns error if profile was not selected and tag has only sections of profiles, because field would be silently not linked.
+func fieldTag(f reflect.StructField, tagName string, profile string) (string, error) {
+    tag := f.Tag.Get(tagName)
+    fromTag := tags.Profile(tag, profile)
+
+    if len(profile) == 0 && len(tag) > 0 && len(fromTag) == 0 {
+        return "", errors.New(fmt.Sprintf("There is no section without name of profile at tag '%s' of field '%s'. You must select profile via Config.Profile.", tag, f.Name))
+    }
+
+    return fromTag, nil
+}
+
 // getTagMapping returns a mapping extracted from tags tagName of struct t that can be used to link fields.
-func getTagMapping(t *mapperType, tagName string, reverse bool) (map[string]string, error) {
+func getTagMapping(t *mapperType, tagName string) (map[string]string, error) {
     if t.normalizedType.Kind() != reflect.Struct {
         return nil, errors.New("Only struct supports mapping via tags. You must provide manual mapping for other types.")
     }
 
     tagMapping := make(map[string]string)
-    for fieldName, field := range t.fields {
-        f := t.normalizedType.Field(field.id)
-
-        if fromTag := f.Tag.Get(tagName); len(fromTag) > 0 {
-            if reverse {
-                tagMapping[fromTag] = fieldName
-            } else {
-                tagMapping[fieldName] = fromTag
+    for i, i_max := 0, t.normalizedType.NumField(); i < i_max; i++ {
+        f := t.normalizedType.Field(i)
+        fieldName := t.fieldName(f.Name)
+        if len(f.PkgPath) > 0 && !f.Anonymous {
+            continue
+        }
+
+        fromTag, err := fieldTag(f, tagName, t.config.Profile)
+        if err != nil {
+            return nil, err
+        }
+
+        if len(fromTag) > 0 {
+            _, options := tags.Parse(fromTag)
+
+            //fields of inlined struct are linked via own tags
+            if options.Contains("inline") {
+                prefix, _ := options.Value("prefix")
+                inlineMapping, err := (*StructMapper)(t).inline(fieldName, tagName, prefix)
+                if err != nil {
+                    return nil, err
+                }
+
+                for from, to := range inlineMapping {
+                    tagMapping[from] = to
+                }
+
+                continue
             }
+
+            tagMapping[fieldName] = fromTag
         }
     }
 
@@ -1,6 +1,7 @@
 package remapper
 
 import (
+    "reflect"
     "testing"
 )
 
@@ -14,6 +15,29 @@ type MyStruct struct {
 
 var result interface{}
 
+var myStructNames = []string{"int_val", "uint_val", "str_val", "float_val", "bool_val"}
+
+// benchmarkMap measures mapping of same 'from' via mapper. Mapping is checked once, so errors are not checked while measuring.
+func benchmarkMap(b *testing.B, mapper *Mapper, err error, from interface{}) {
+    if err != nil {
+        b.Fatal(err)
+    }
+
+    if _, err := mapper.Map(from); err != nil {
+        b.Fatal(err)
+    }
+
+    b.ReportAllocs()
+    b.ResetTimer()
+
+    var r interface{}
+    for n := 0; n < b.N; n++ {
+        r, _ = mapper.Map(from)
+    }
+
+    result = r
+}
+
 func BenchmarkFromNamedTypedArrayToStruct(b *testing.B) {
     mapper, err := New(&MyStruct{}, Slice([]string{}, []string{
         "int_val",
@@ -67,3 +91,83 @@ func BenchmarkFromNamedUntypedArrayToStruct(b *testing.B) {
 
     result = r
 }
+
+func BenchmarkFromNamedTypedArrayToStructReusedInput(b *testing.B) {
+    mapper, err := New(&MyStruct{}, Slice([]string{}, myStructNames))
+    benchmarkMap(b, mapper, err, &[]string{"-1", "1", "test string", "1.2345", "true"})
+}
+
+func BenchmarkFromNamedUntypedArrayToStructReusedInput(b *testing.B) {
+    mapper, err := New(&MyStruct{}, Slice([]interface{}{}, myStructNames))
+    benchmarkMap(b, mapper, err, &[]interface{}{-1, 1, "test string", 1.2345, true})
+}
+
+func BenchmarkFromStructToNamedTypedArray(b *testing.B) {
+    mapper, err := New(&MyStruct{}, Slice([]string{}, myStructNames))
+    benchmarkMap(b, mapper, err, &MyStruct{-1, 1, "test string", 1.2345, true})
+}
+
+func BenchmarkFromStructToMap(b *testing.B) {
+    mapper, err := New(&MyStruct{}, map[string]string{})
+    benchmarkMap(b, mapper, err, &MyStruct{-1, 1, "test string", 1.2345, true})
+}
+
+func BenchmarkFromMapToStruct(b *testing.B) {
+    mapper, err := New(&MyStruct{}, map[string]string{})
+    benchmarkMap(b, mapper, err, map[string]string{
+        "int_val":   "-1",
+        "uint_val":  "1",
+        "str_val":   "test string",
+        "float_val": "1.2345",
+        "bool_val":  "true",
+    })
+}
+
+func BenchmarkFromMapWithOtherCaseToStruct(b *testing.B) {
+    mapper, err := New(&MyStruct{}, map[string]string{})
+    benchmarkMap(b, mapper, err, map[string]string{
+        "INT_VAL":   "-1",
+        "UINT_VAL":  "1",
+        "STR_VAL":   "test string",
+        "FLOAT_VAL": "1.2345",
+        "BOOL_VAL":  "true",
+    })
+}
+
+func BenchmarkFromUntypedArrayToStructSameTypes(b *testing.B) {
+    mapper, err := New(&MyStruct{}, Slice([]interface{}{}, myStructNames))
+    benchmarkMap(b, mapper, err, []interface{}{-1, uint(1), "test string", 1.2345, true})
+}
+
+func BenchmarkMapIntoStruct(b *testing.B) {
+    mapper, err := New(&MyStruct{}, Slice([]string{}, myStructNames))
+    if err != nil {
+        b.Fatal(err)
+    }
+
+    from := []string{"-1", "1", "test string", "1.2345", "true"}
+    to := &MyStruct{}
+
+    if err := mapper.MapInto(from, to); err != nil {
+        b.Fatal(err)
+    }
+
+    b.ReportAllocs()
+    b.ResetTimer()
+
+    for n := 0; n < b.N; n++ {
+        _ = mapper.MapInto(from, to)
+    }
+
+    result = to
+}
+
+func BenchmarkFromNamedTypedArrayToStructWithoutFastPath(b *testing.B) {
+    //custom converter disables direct conversion into fields
+    converter := func(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
+        return Convert(from, toType)
+    }
+
+    mapper, err := New(&MyStruct{}, Slice([]string{}, myStructNames), Config{ValueConverter: converter})
+    benchmarkMap(b, mapper, err, []string{"-1", "1", "test string", "1.2345", "true"})
+}
@@ -3,6 +3,8 @@ package remapper
 import (
     "testing"
     "reflect"
+    "strconv"
+    "sync"
     "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/require"
 )
@@ -122,6 +124,34 @@ func TestEmptyMapping(t *testing.T) {
     assert.Nil(t, s)
 }
 
+func TestUnexportedFields(t *testing.T) {
+    type TestStructUnexported struct {
+        ID   int    `remapper:"id"`
+        id   int    `remapper:"0"`
+        Name string `remapper:"name"`
+        note string
+    }
+
+    mapper, err := New(TestStructUnexported{}, Slice([]string{}, []string{"id", "name"}))
+    require.Nil(t, err)
+
+    s, err := mapper.Map([]string{"1", "John"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructUnexported{ID: 1, Name: "John"}, s)
+
+    a, err := mapper.Map(TestStructUnexported{ID: 1, id: 2, Name: "John", note: "test"})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"1", "John"}, a)
+
+    //names of map are derived from exported fields only
+    mapper, err = New(TestStructUnexported{}, map[string]string{})
+    require.Nil(t, err)
+
+    m, err := mapper.Map(TestStructUnexported{ID: 1, id: 2, Name: "John", note: "test"})
+    require.Nil(t, err)
+    assert.Equal(t, map[string]string{"id": "1", "name": "John"}, m)
+}
+
 func TestFromNamedUntypedArrayWithTags(t *testing.T) {
     mapper, err := New(Slice(arrayUntyped, arrayFieldNames), TestStructNamed{})
     require.Nil(t, err)
@@ -208,8 +238,9 @@ func TestToIndexedUntypedArrayWithTags(t *testing.T) {
     require.NotNil(t, mapper)
 
     a, err := mapper.Map(mappedIndexedStruct)
-    assert.NotNil(t, err)
-    assert.Nil(t, a)
+    require.Nil(t, err)
+    assert.IsType(t, []interface{}{}, a)
+    assert.Equal(t, arrayUntyped[:7], a)
 }
 
 func TestFromIndexedUntypedArrayWithMapping(t *testing.T) {
@@ -230,8 +261,9 @@ func TestToIndexedUntypedArrayWithMapping(t *testing.T) {
     require.NotNil(t, mapper)
 
     a, err := mapper.Map(mappedIndexedStruct)
-    assert.NotNil(t, err)
-    assert.Nil(t, a)
+    require.Nil(t, err)
+    assert.IsType(t, []interface{}{}, a)
+    assert.Equal(t, arrayUntyped[:7], a)
 }
 
 func TestFromNamedTypedArrayWithTags(t *testing.T) {
@@ -320,8 +352,9 @@ func TestToIndexedTypedArrayWithTags(t *testing.T) {
     require.NotNil(t, mapper)
 
     a, err := mapper.Map(mappedIndexedStruct)
-    assert.NotNil(t, err)
-    assert.Nil(t, a)
+    require.Nil(t, err)
+    assert.IsType(t, []string{}, a)
+    assert.Equal(t, arrayTyped[:7], a)
 }
 
 func TestFromIndexedTypedArrayWithMapping(t *testing.T) {
@@ -342,19 +375,83 @@ func TestToIndexedTypedArrayWithMapping(t *testing.T) {
     require.NotNil(t, mapper)
 
     a, err := mapper.Map(mappedIndexedStruct)
-    assert.NotNil(t, err)
-    assert.Nil(t, a)
+    require.Nil(t, err)
+    assert.IsType(t, []string{}, a)
+    assert.Equal(t, arrayTyped[:7], a)
 }
 
-//TODO: refactor. Now it's possible
 func TestArraySameType(t *testing.T) {
-    //mapper, err := New(TestStructIndexed{}, TestStructIndexed{}, structToIndexedArrayMapping)
-    //require.NotNil(t, err)
-    //require.Nil(t, mapper)
-    //
-    //mapper, err = New(arrayTyped, arrayTyped, structToIndexedArrayMapping)
-    //require.NotNil(t, err)
-    //require.Nil(t, mapper)
+    //columns of slice are reordered
+    mapper, err := New(Slice([]string{}, []string{"a", "b", "c"}), Slice([]string{}, []string{"c", "a", "b"}), map[string]string{"a": "a", "b": "b", "c": "c"})
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    a, err := mapper.Forward([]string{"1", "2", "3"})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"3", "1", "2"}, a)
+
+    a, err = mapper.Backward([]string{"3", "1", "2"})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"1", "2", "3"}, a)
+
+    //direction can't be resolved via type
+    _, err = mapper.Map([]string{"1", "2", "3"})
+    assert.NotNil(t, err)
+    assert.NotNil(t, mapper.MapInto([]string{"1", "2", "3"}, &[]string{}))
+
+    to := []string{"x"}
+    require.Nil(t, mapper.ForwardInto([]string{"1", "2", "3"}, &to))
+    assert.Equal(t, []string{"3", "1", "2"}, to)
+
+    //projection of struct into same struct
+    mapper, err = New(&TestStructIndexed{}, &TestStructIndexed{}, map[string]string{"IntVal": "UintVal", "StrVal": "StrVal"})
+    require.Nil(t, err)
+
+    s, err := mapper.Forward(TestStructIndexed{IntVal: 1, UintVal: 2, StrVal: "test string", BoolVal: true})
+    require.Nil(t, err)
+    assert.Equal(t, &TestStructIndexed{UintVal: 1, StrVal: "test string"}, s)
+
+    s, err = mapper.Backward(&TestStructIndexed{IntVal: 1, UintVal: 2, StrVal: "test string"})
+    require.Nil(t, err)
+    assert.Equal(t, &TestStructIndexed{IntVal: 2, StrVal: "test string"}, s)
+
+    //type must match direction
+    mapper, err = New(TestStructIndexed{}, arrayTyped)
+    require.Nil(t, err)
+
+    _, err = mapper.Forward(arrayTyped)
+    assert.NotNil(t, err)
+    _, err = mapper.Forward(nil)
+    assert.NotNil(t, err)
+    assert.NotNil(t, mapper.BackwardInto(TestStructIndexed{}, &TestStructIndexed{}))
+
+    s, err = mapper.Backward(arrayTyped)
+    require.Nil(t, err)
+    assert.Equal(t, TestStructIndexed{IntVal: -1, UintVal: 1, StrVal: "test string", FloatVal: 1.2345, BoolVal: true}, s)
+
+    //pair between same types
+    pair, err := NewPair[[]string, []string](Slice([]string{}, []string{"a", "b"}), Slice([]string{}, []string{"b", "a"}), map[string]string{"a": "a", "b": "b"})
+    require.Nil(t, err)
+
+    row, err := pair.Forward([]string{"1", "2"})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"2", "1"}, row)
+
+    rows, err := pair.BackwardAll([][]string{{"2", "1"}, {"4", "3"}})
+    require.Nil(t, err)
+    assert.Equal(t, [][]string{{"1", "2"}, {"3", "4"}}, rows)
+
+    //rows of mapper between same types are mapped with explicit direction
+    _, err = pair.Mapper().MapAll([][]string{{"1", "2"}}, BatchOptions{})
+    assert.NotNil(t, err)
+
+    results, err := pair.Mapper().MapAll([][]string{{"1", "2"}, {"3", "4"}}, BatchOptions{Workers: 2, Direction: ForwardDirection})
+    require.Nil(t, err)
+    assert.Equal(t, []interface{}{[]string{"2", "1"}, []string{"4", "3"}}, results)
+
+    results, err = pair.Mapper().MapAll([][]string{{"2", "1"}}, BatchOptions{Direction: BackwardDirection})
+    require.Nil(t, err)
+    assert.Equal(t, []interface{}{[]string{"1", "2"}}, results)
 }
 
 func TestNamedArrayNameByName(t *testing.T) {
@@ -368,7 +465,7 @@ func TestNamedArrayNameByName(t *testing.T) {
 
     reverseName, err = mapper.NameByName(arrayTyped, "float_val")
     require.Nil(t, err)
-    assert.Equal(t, "floatval", reverseName)
+    assert.Equal(t, "FloatVal", reverseName)
 }
 
 func TestIndexedArrayNameByName(t *testing.T) {
@@ -524,7 +621,7 @@ func TestCustomTagArrayNameByName(t *testing.T) {
 
     reverseName, err = mapper.NameByName(arrayTyped, "float_val")
     require.Nil(t, err)
-    assert.Equal(t, "floatval", reverseName)
+    assert.Equal(t, "FloatVal", reverseName)
 }
 
 func testMapperIget(t *testing.T, mapper mapperType, target reflect.Value, i int, n string, value interface{}) {
@@ -596,3 +693,600 @@ func testMapperItyped(t *testing.T, mapper mapperType, target reflect.Value) {
     testMapperIget(t, mapper, target, 4, "BoolVal", "true")
 }
 
+
+type TestAddress struct {
+    Street string `remapper:"street"`
+    City   string `remapper:"city"`
+}
+
+type TestStructInline struct {
+    Name     string      `remapper:"name"`
+    Billing  TestAddress `remapper:",inline,prefix=billing_"`
+    Shipping TestAddress `remapper:",inline,prefix=shipping_"`
+}
+
+var mappedInlineStruct = TestStructInline{
+    Name:     "John",
+    Billing:  TestAddress{"Main St. 1", "London"},
+    Shipping: TestAddress{"Second St. 2", "Paris"},
+}
+
+func TestInlineNamedArray(t *testing.T) {
+    names := []string{"name", "billing_street", "billing_city", "shipping_street", "shipping_city"}
+    array := []string{"John", "Main St. 1", "London", "Second St. 2", "Paris"}
+
+    //struct is first type
+    mapper, err := New(TestStructInline{}, Slice([]string{}, names))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    s, err := mapper.Map(array)
+    require.Nil(t, err)
+    assert.Equal(t, mappedInlineStruct, s)
+
+    a, err := mapper.Map(mappedInlineStruct)
+    require.Nil(t, err)
+    assert.Equal(t, array, a)
+
+    v, err := mapper.GetByName(mappedInlineStruct, "Shipping.City")
+    require.Nil(t, err)
+    assert.Equal(t, "Paris", v)
+
+    reverseName, err := mapper.NameByName(mappedInlineStruct, "Billing.Street")
+    require.Nil(t, err)
+    assert.Equal(t, "billing_street", reverseName)
+
+    //struct is second type
+    mapper, err = New(Slice([]string{}, names), TestStructInline{})
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    s, err = mapper.Map(array)
+    require.Nil(t, err)
+    assert.Equal(t, mappedInlineStruct, s)
+
+    //manual mapping
+    mapper, err = New(TestStructInline{}, Slice([]string{}, names), map[string]string{
+        "Name":     "name",
+        "Shipping": ",inline,prefix=shipping_",
+    })
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    s, err = mapper.Map(array)
+    require.Nil(t, err)
+    assert.Equal(t, TestStructInline{Name: "John", Shipping: mappedInlineStruct.Shipping}, s)
+}
+
+func TestInlineIndexedArray(t *testing.T) {
+    type TestAddress struct {
+        Street string `remapper:"0"`
+        City   string `remapper:"1"`
+    }
+
+    type TestStructInline struct {
+        Name     string      `remapper:"0"`
+        Billing  TestAddress `remapper:",inline,prefix=1"`
+        Shipping TestAddress `remapper:",inline,prefix=3"`
+    }
+
+    mapper, err := New([]string{}, TestStructInline{})
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    s, err := mapper.Map([]string{"John", "Main St. 1", "London", "Second St. 2", "Paris"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructInline{
+        Name:     "John",
+        Billing:  TestAddress{"Main St. 1", "London"},
+        Shipping: TestAddress{"Second St. 2", "Paris"},
+    }, s)
+
+    //prefix of indexes must be a number
+    type TestStructNamedPrefix struct {
+        Billing  TestAddress `remapper:",inline,prefix=billing_"`
+        Shipping TestAddress `remapper:",inline,prefix=shipping_"`
+    }
+
+    mapper, err = New(&TestStructNamedPrefix{}, []string{})
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+
+    //index can be linked only once
+    type TestStructOverlapped struct {
+        Billing  TestAddress `remapper:",inline,prefix=1"`
+        Shipping TestAddress `remapper:",inline,prefix=2"`
+    }
+
+    mapper, err = New(&TestStructOverlapped{}, []string{})
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+
+    type TestStructSameIndex struct {
+        Name  string `remapper:"0"`
+        Title string `remapper:"0"`
+    }
+
+    mapper, err = New(&TestStructSameIndex{}, []string{})
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+
+    type TestStructCombinedIndex struct {
+        Name string `remapper:"0"`
+        Full string `remapper:"0+1,join= "`
+    }
+
+    mapper, err = New(&TestStructCombinedIndex{}, []string{})
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+}
+
+func TestInlineMap(t *testing.T) {
+    mapper, err := New(TestStructInline{}, Map(map[string]string{}, []string{"name", "billing_street", "billing_city", "shipping_street", "shipping_city"}))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    m, err := mapper.Map(mappedInlineStruct)
+    require.Nil(t, err)
+    assert.Equal(t, map[string]string{
+        "name":            "John",
+        "billing_street":  "Main St. 1",
+        "billing_city":    "London",
+        "shipping_street": "Second St. 2",
+        "shipping_city":   "Paris",
+    }, m)
+
+    s, err := mapper.Map(m)
+    require.Nil(t, err)
+    assert.Equal(t, mappedInlineStruct, s)
+}
+
+func TestWithHeader(t *testing.T) {
+    mapper, err := New(TestStructNamed{}, Slice(arrayTyped, arrayFieldNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    bound, err := mapper.WithHeader([]string{"bool_val", "str_val", "unknown", "int_val", "float_val"})
+    require.Nil(t, err)
+    require.NotNil(t, bound)
+
+    s, err := bound.Map([]string{"true", "test string", "?", "-1", "1.2345"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructNamed{
+        IntVal:   -1,
+        StrVal:   "test string",
+        FloatVal: 1.2345,
+        BoolVal:  true,
+    }, s)
+
+    a, err := bound.Map(s)
+    require.Nil(t, err)
+    assert.Equal(t, []string{"true", "test string", "", "-1", "1.2345"}, a)
+
+    reverseName, err := bound.NameByName(s, "IntVal")
+    require.Nil(t, err)
+    assert.Equal(t, "int_val", reverseName)
+
+    //original mapper is not affected
+    s, err = mapper.Map(arrayTyped)
+    require.Nil(t, err)
+    assert.Equal(t, mappedNamedStruct, s)
+
+    //duplicated columns
+    _, err = mapper.WithHeader([]string{"int_val", "Int_Val"})
+    assert.NotNil(t, err)
+
+    //blank and repeated columns that are not linked
+    bound, err = mapper.WithHeader([]string{"int_val", "", "", "unknown", "Unknown"})
+    require.Nil(t, err)
+
+    s, err = bound.Map([]string{"-1", "a", "b", "c", "d"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructNamed{IntVal: -1}, s)
+
+    a, err = bound.Map(s)
+    require.Nil(t, err)
+    assert.Equal(t, []string{"-1", "", "", "", ""}, a)
+
+    //indexed slice can't be bound
+    mapper, err = New(TestStructIndexed{}, arrayTyped)
+    require.Nil(t, err)
+
+    _, err = mapper.WithHeader([]string{"int_val"})
+    assert.NotNil(t, err)
+}
+
+func TestWithHeaderRequired(t *testing.T) {
+    type TestStructRequired struct {
+        IntVal int    `remapper:"int_val,required"`
+        StrVal string `remapper:"str_val"`
+    }
+
+    mapper, err := New(TestStructRequired{}, Slice([]string{}, []string{"int_val", "str_val"}))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    _, err = mapper.WithHeader([]string{"str_val"})
+    require.NotNil(t, err)
+    assert.Contains(t, err.Error(), "int_val")
+
+    bound, err := mapper.WithHeader([]string{"int_val"})
+    require.Nil(t, err)
+
+    s, err := bound.Map([]string{"10"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructRequired{IntVal: 10}, s)
+}
+
+func TestSparseIndexedArray(t *testing.T) {
+    type TestStructSparse struct {
+        IntVal  int    `remapper:"0"`
+        StrVal  string `remapper:"5"`
+        BoolVal bool   `remapper:"9"`
+    }
+
+    mapper, err := New(TestStructSparse{}, []string{})
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    a, err := mapper.Map(TestStructSparse{-1, "test string", true})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"-1", "", "", "", "", "test string", "", "", "", "true"}, a)
+
+    //explicit width and placeholder
+    mapper, err = New(TestStructSparse{}, Slice([]string{}, SliceOptions{Width: 12, Placeholder: "-"}))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    a, err = mapper.Map(TestStructSparse{-1, "test string", true})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"-1", "-", "-", "-", "-", "test string", "-", "-", "-", "true", "-", "-"}, a)
+
+    //index out of width
+    mapper, err = New(TestStructSparse{}, Slice([]string{}, SliceOptions{Width: 6}))
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+
+    mapper, err = New(TestStructSparse{}, Slice([]string{}, 6))
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+
+    //invalid placeholder
+    mapper, err = New(TestStructSparse{}, Slice([]string{}, SliceOptions{Placeholder: 1}))
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+
+    //value can't be placed
+    mapper, err = New(TestStructSparse{}, []string{})
+    require.Nil(t, err)
+
+    err = mapper.SetByName(&[]string{"", ""}, "9", "true")
+    assert.NotNil(t, err)
+}
+
+func TestArray(t *testing.T) {
+    var array [8]string
+    copy(array[:], arrayTyped)
+
+    mapper, err := New(TestStructIndexed{}, [8]string{})
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    s, err := mapper.Map(array)
+    require.Nil(t, err)
+    assert.Equal(t, mappedIndexedStruct, s)
+
+    a, err := mapper.Map(mappedIndexedStruct)
+    require.Nil(t, err)
+    assert.IsType(t, [8]string{}, a)
+    assert.Equal(t, array, a)
+
+    //in-place mapping via pointer to array
+    mapper, err = New(TestStructNamed{}, Slice(&[8]string{}, arrayFieldNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    a, err = mapper.Map(mappedNamedStruct)
+    require.Nil(t, err)
+    assert.IsType(t, &[8]string{}, a)
+    assert.Equal(t, &array, a)
+
+    target := [8]string{}
+    err = mapper.SetByName(&target, "float_val", "5432.1")
+    require.Nil(t, err)
+    assert.Equal(t, [8]string{"", "", "", "", "", "5432.1", "", ""}, target)
+
+    //index out of range of array
+    mapper, err = New(TestStructIndexed{}, [6]string{})
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+}
+
+func TestFieldNameCollision(t *testing.T) {
+    mapper, err := New(TestStructNamed{}, Slice(arrayTyped, []string{"int_val", "Int_Val"}))
+    require.NotNil(t, err)
+    assert.Nil(t, mapper)
+}
+
+func TestInlineInvalidType(t *testing.T) {
+    type TestStructInline struct {
+        Name string `remapper:",inline,prefix=name_"`
+    }
+
+    mapper, err := New(TestStructInline{}, Slice([]string{}, []string{"name_first"}))
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+}
+
+func TestUntypedMap(t *testing.T) {
+    mapNames := []string{"int_val", "uint_val", "str_val", "float_val", "bool_val"}
+
+    mapper, err := New(TestStructNamed{}, Map(map[string]interface{}{}, mapNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    m, err := mapper.Map(mappedNamedStruct)
+    require.Nil(t, err)
+    assert.Equal(t, map[string]interface{}{
+        "int_val":   -1,
+        "uint_val":  uint(1),
+        "str_val":   "test string",
+        "float_val": 1.2345,
+        "bool_val":  true,
+    }, m)
+
+    //e.g. from encoding/json
+    s, err := mapper.Map(map[string]interface{}{
+        "int_val":   float64(-1),
+        "uint_val":  "1",
+        "str_val":   "test string",
+        "float_val": 1.2345,
+        "bool_val":  nil,
+    })
+    require.Nil(t, err)
+    assert.Equal(t, TestStructNamed{-1, 1, "test string", 1.2345, false}, s)
+
+    //with type of values
+    mapper, err = New(TestStructNamed{}, Map(map[string]interface{}{}, MapOptions{Names: mapNames, ValueType: reflect.TypeOf("")}))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    m, err = mapper.Map(mappedNamedStruct)
+    require.Nil(t, err)
+    assert.Equal(t, map[string]interface{}{
+        "int_val":   "-1",
+        "uint_val":  "1",
+        "str_val":   "test string",
+        "float_val": "1.2345",
+        "bool_val":  "true",
+    }, m)
+}
+
+func TestMapNamesFromStruct(t *testing.T) {
+    type TestStructMixed struct {
+        IntVal   int     `remapper:"int_val"`
+        StrVal   string  `remapper:"str_val"`
+        FloatVal float64
+        BoolVal  bool
+        private  bool
+    }
+
+    mapper, err := New(&TestStructMixed{}, map[string]string{})
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    m, err := mapper.Map(&TestStructMixed{-1, "test string", 1.2345, true, true})
+    require.Nil(t, err)
+    assert.Equal(t, map[string]string{
+        "int_val":  "-1",
+        "str_val":  "test string",
+        "FloatVal": "1.2345",
+        "BoolVal":  "true",
+    }, m)
+
+    s, err := mapper.Map(m)
+    require.Nil(t, err)
+    assert.Equal(t, &TestStructMixed{-1, "test string", 1.2345, true, false}, s)
+
+    //inlined structs
+    mapper, err = New(Map(map[string]interface{}{}, nil), TestStructInline{})
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    m, err = mapper.Map(mappedInlineStruct)
+    require.Nil(t, err)
+    assert.Equal(t, map[string]interface{}{
+        "name":            "John",
+        "billing_street":  "Main St. 1",
+        "billing_city":    "London",
+        "shipping_street": "Second St. 2",
+        "shipping_city":   "Paris",
+    }, m)
+
+    //names can't be derived for manual mapping
+    mapper, err = New(TestStructNamed{}, map[string]string{}, structToNamedArrayMapping)
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+}
+
+type testMapKey string
+
+type testTextMapKey struct {
+    name string
+}
+
+func (k testTextMapKey) MarshalText() ([]byte, error) {
+    return []byte(k.name), nil
+}
+
+func (k *testTextMapKey) UnmarshalText(text []byte) (error) {
+    k.name = string(text)
+    return nil
+}
+
+func TestMapKeys(t *testing.T) {
+    mapNames := []string{"int_val", "uint_val", "str_val", "float_val", "bool_val"}
+
+    //keys of int type
+    mapper, err := New(TestStructIndexed{}, map[int]string{})
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    m, err := mapper.Map(mappedIndexedStruct)
+    require.Nil(t, err)
+    assert.Equal(t, map[int]string{1: "-1", 2: "1", 4: "test string", 5: "1.2345", 6: "true"}, m)
+
+    s, err := mapper.Map(m)
+    require.Nil(t, err)
+    assert.Equal(t, mappedIndexedStruct, s)
+
+    //keys of named string type
+    mapper, err = New(TestStructNamed{}, Map(map[testMapKey]string{}, mapNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    m, err = mapper.Map(mappedNamedStruct)
+    require.Nil(t, err)
+    assert.Equal(t, map[testMapKey]string{"int_val": "-1", "uint_val": "1", "str_val": "test string", "float_val": "1.2345", "bool_val": "true"}, m)
+
+    s, err = mapper.Map(m)
+    require.Nil(t, err)
+    assert.Equal(t, mappedNamedStruct, s)
+
+    //keys that implement encoding.TextUnmarshaler
+    mapper, err = New(TestStructNamed{}, Map(map[testTextMapKey]string{}, mapNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    m, err = mapper.Map(mappedNamedStruct)
+    require.Nil(t, err)
+    assert.Equal(t, "test string", m.(map[testTextMapKey]string)[testTextMapKey{"str_val"}])
+
+    s, err = mapper.Map(m)
+    require.Nil(t, err)
+    assert.Equal(t, mappedNamedStruct, s)
+
+    //names that can't be converted to keys
+    mapper, err = New(TestStructNamed{}, Map(map[int]string{}, mapNames))
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+}
+
+func TestMapKeysCase(t *testing.T) {
+    type TestStructUser struct {
+        UserID    int    `remapper:"UserID"`
+        CreatedAt string `remapper:"CreatedAt"`
+    }
+
+    mapper, err := New(TestStructUser{}, Map(map[string]string{}, []string{"UserID", "CreatedAt"}))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    //keys are written as configured
+    m, err := mapper.Map(TestStructUser{1, "2020-01-01"})
+    require.Nil(t, err)
+    assert.Equal(t, map[string]string{"UserID": "1", "CreatedAt": "2020-01-01"}, m)
+
+    //keys are matched case-insensitive
+    s, err := mapper.Map(map[string]string{"userid": "1", "CREATEDAT": "2020-01-01"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructUser{1, "2020-01-01"}, s)
+
+    v, err := mapper.GetByName(m, "userId")
+    require.Nil(t, err)
+    assert.Equal(t, "1", v)
+
+    //keys of combined fields are matched case-insensitive
+    type TestStructDate struct {
+        Date string `remapper:"Year+Month,join=-"`
+    }
+
+    mapper, err = New(TestStructDate{}, Map(map[string]string{}, []string{"Year", "Month"}))
+    require.Nil(t, err)
+
+    s, err = mapper.Map(map[string]string{"YEAR": "2020", "month": "01"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructDate{"2020-01"}, s)
+
+    mapper, err = New(TestStructUser{}, Map(map[string]string{}, []string{"UserID", "CreatedAt"}))
+    require.Nil(t, err)
+
+    //keys that differ only by case
+    type TestStructCase struct {
+        Lower string `remapper:"id"`
+        Upper string `remapper:"ID"`
+    }
+
+    _, err = New(TestStructCase{}, Map(map[string]string{}, []string{"id", "ID"}))
+    assert.NotNil(t, err)
+
+    mapper, err = New(TestStructCase{}, Map(map[string]string{}, MapOptions{Names: []string{"id", "ID"}, CaseSensitive: true}))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    m, err = mapper.Map(TestStructCase{"lower", "upper"})
+    require.Nil(t, err)
+    assert.Equal(t, map[string]string{"id": "lower", "ID": "upper"}, m)
+
+    s, err = mapper.Map(map[string]string{"id": "lower", "ID": "upper"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructCase{"lower", "upper"}, s)
+
+    //case-sensitive keys are not matched with other case
+    s, err = mapper.Map(map[string]string{"Id": "lower", "iD": "upper"})
+    require.Nil(t, err)
+    assert.Nil(t, s)
+}
+
+func TestConcurrentMapper(t *testing.T) {
+    mapper, err := New(TestStructNamed{}, Slice([]string{}, arrayFieldNames))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    mapMapper, err := New(&TestStructNamed{}, Map(map[string]interface{}{}, nil))
+    require.Nil(t, err)
+    require.NotNil(t, mapMapper)
+
+    var wg sync.WaitGroup
+    for i := 0; i < 16; i++ {
+        wg.Add(1)
+
+        go func(i int) {
+            defer wg.Done()
+
+            row := []string{"", strconv.Itoa(i), "1", "", "test string", "1.2345", "true", ""}
+            s, err := mapper.Map(row)
+            assert.Nil(t, err)
+            assert.Equal(t, i, s.(TestStructNamed).IntVal)
+
+            arr, err := mapper.Map(s)
+            assert.Nil(t, err)
+            assert.Equal(t, row, arr)
+
+            var into TestStructNamed
+            assert.Nil(t, mapper.WithMerge(MergeZero).MapInto(row, &into))
+            assert.Equal(t, s, into)
+
+            assert.Nil(t, mapper.SetByName(&into, "StrVal", "other string"))
+            v, err := mapper.GetByName(into, "StrVal")
+            assert.Nil(t, err)
+            assert.Equal(t, "other string", v)
+
+            name, err := mapper.NameByName(into, "StrVal")
+            assert.Nil(t, err)
+            assert.Equal(t, "str_val", name)
+
+            bound, err := mapper.WithHeader([]string{"str_val", "int_val"})
+            assert.Nil(t, err)
+            s, err = bound.Map([]string{"test string", strconv.Itoa(i)})
+            assert.Nil(t, err)
+            assert.Equal(t, i, s.(TestStructNamed).IntVal)
+
+            m, err := mapMapper.Map(&TestStructNamed{IntVal: i, StrVal: "test string"})
+            assert.Nil(t, err)
+            assert.Equal(t, i, m.(map[string]interface{})["int_val"])
+        }(i)
+    }
+
+    wg.Wait()
+}
@@ -0,0 +1,40 @@
+// Package remappertest provides helpers to test code that replaces remapper.Mapper, e.g. functions generated by remapper-gen.
+package remappertest
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/plandem/remapper"
+)
+
+// Compare maps each of inputs via mapper and via generated function and reports any difference of results or errors.
+// Result of mapper that is nil, i.e. nothing was mapped, is compared as zero value of Out.
+func Compare[In any, Out any](t testing.TB, mapper *remapper.Mapper, generated func(In) (Out, error), inputs ...In) {
+    t.Helper()
+
+    for i, input := range inputs {
+        expected, expectedErr := mapper.Map(input)
+        actual, actualErr := generated(input)
+
+        if (expectedErr == nil) != (actualErr == nil) || (expectedErr != nil && expectedErr.Error() != actualErr.Error()) {
+            t.Errorf("Input %d: error of mapper is '%v', but error of generated function is '%v'", i, expectedErr, actualErr)
+            continue
+        }
+
+        var expectedOut Out
+        if expected != nil {
+            out, ok := expected.(Out)
+            if !ok {
+                t.Errorf("Input %d: mapper returns '%T', but generated function returns '%s'", i, expected, reflect.TypeOf((*Out)(nil)).Elem())
+                continue
+            }
+
+            expectedOut = out
+        }
+
+        if !reflect.DeepEqual(expectedOut, actual) {
+            t.Errorf("Input %d: mapper returns '%#v', but generated function returns '%#v'", i, expectedOut, actual)
+        }
+    }
+}
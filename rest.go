@@ -0,0 +1,335 @@
+package remapper
+
+import (
+    "errors"
+    "fmt"
+    "reflect"
+    "strconv"
+
+    "github.com/plandem/remapper/internal/tags"
+)
+
+// resolveRestField configures a field of struct t to collect data of linked type that is not linked to any other field.
+//
+// - for slice it can be a slice, e.g.: []string, that holds not linked values in order of positions or a map, e.g.: map[string]string, that holds them by names or indexes
+// - for map it can be a map only, e.g.: map[string]string, that holds not linked values by keys
+func resolveRestField(t *mapperType, fieldName string, linkedType *mapperType, options tags.Options) (error) {
+    if t.normalizedType.Kind() != reflect.Struct {
+        return errors.New(fmt.Sprintf("Only field of struct can collect not linked data, but got '%s' at %v", fieldName, t.normalizedType))
+    }
+
+    field := t.fields[fieldName]
+    if restName, _ := getRestField(t); len(restName) > 0 && restName != fieldName {
+        return errors.New(fmt.Sprintf("Only one field can collect not linked data, but got '%s' and '%s' at %v", t.fields[restName].name, field.name, t.normalizedType))
+    }
+
+    restType := t.normalizedType.FieldByIndex(field.index).Type
+    switch {
+    case restType.Kind() == reflect.Map && restType.Key().Kind() == reflect.String:
+    case restType.Kind() == reflect.Slice && (linkedType.normalizedType.Kind() == reflect.Slice || linkedType.normalizedType.Kind() == reflect.Array):
+    default:
+        return errors.New(fmt.Sprintf("Invalid type '%s' of field '%s' to collect not linked data of %v", restType, field.name, linkedType.normalizedType))
+    }
+
+    field.resolveOptions(options)
+    return nil
+}
+
+// getRestField returns a field of struct t that collects not linked data or nil if there is no such field
+func getRestField(t *mapperType) (string, *mapperField) {
+    for fieldName, field := range t.fields {
+        if field.rest {
+            return fieldName, field
+        }
+    }
+
+    return "", nil
+}
+
+// getLinkedKeys returns indexes and names of linkedType that are linked to fields of struct t
+func getLinkedKeys(t *mapperType, linkedType *mapperType) (map[int]bool, map[string]bool) {
+    indexes := make(map[int]bool)
+    names := make(map[string]bool)
+
+    for _, field := range t.fields {
+        if field.combined != nil && field.reverseId < 0 {
+            for _, name := range field.combined.names {
+                if linkedField, ok := linkedType.fields[name]; ok {
+                    indexes[linkedField.id] = true
+                    names[name] = true
+                }
+            }
+        }
+
+        if field.reverseId >= 0 {
+            indexes[field.reverseId] = true
+
+            if len(field.reverseName) > 0 {
+                names[field.reverseName] = true
+            }
+        }
+    }
+
+    return indexes, names
+}
+
+// mapRest maps not linked data between fromType and toType if one of them is a struct with field to collect it. Returns names of fields or keys that were set.
+// Field of struct that collects not linked data is merged with mode as one value, other data are merged with mode by positions or keys.
+func mapRest(fromType *mapperType, from reflect.Value, toType *mapperType, to *reflect.Value, mode MergeMode) ([]string, error) {
+    if toType.normalizedType.Kind() == reflect.Struct {
+        if restName, restField := toType.plan.restName, toType.plan.restField; restField != nil {
+            rest, err := getRest(toType, fromType, from, toType.get(*to, restField.id, restName).Type())
+            if err != nil || rest.Len() == 0 {
+                return nil, err
+            }
+
+            if isSet, err := mergeValue(toType, *to, restField.id, restName, rest, rest, mode); err != nil || !isSet {
+                return nil, err
+            }
+
+            return []string{restField.name}, nil
+        }
+    }
+
+    if fromType.normalizedType.Kind() == reflect.Struct {
+        if restName, restField := fromType.plan.restName, fromType.plan.restField; restField != nil {
+            rest := fromType.get(from, restField.id, restName)
+            if rest.Len() == 0 {
+                return nil, nil
+            }
+
+            return setRest(fromType, toType, to, rest, mode)
+        }
+    }
+
+    return nil, nil
+}
+
+// getRest returns a value of restType with data of from that is not linked to any field of struct t via compiled plan of t
+func getRest(t *mapperType, linkedType *mapperType, from reflect.Value, restType reflect.Type) (reflect.Value, error) {
+    indexes, names := t.plan.linkedIndexes, t.plan.linkedNames
+
+    if restType.Kind() == reflect.Slice {
+        rest := reflect.MakeSlice(restType, 0, 0)
+
+        for i, i_max := 0, from.Len(); i < i_max; i++ {
+            if !indexes[i] {
+                value, err := convertRest(from.Index(i), restType.Elem(), t.config.ValueConverter)
+                if err != nil {
+                    return reflect.Value{}, err
+                }
+
+                rest = reflect.Append(rest, value)
+            }
+        }
+
+        return rest, nil
+    }
+
+    rest := reflect.MakeMap(restType)
+
+    if from.Kind() == reflect.Map {
+        mapMapper := (*MapMapper)(linkedType)
+
+        for _, key := range from.MapKeys() {
+            name := mapMapper.keyName(key)
+            if names[linkedType.fieldName(name)] {
+                continue
+            }
+
+            value, err := convertRest(from.MapIndex(key), restType.Elem(), t.config.ValueConverter)
+            if err != nil {
+                return reflect.Value{}, err
+            }
+
+            rest.SetMapIndex(reflect.ValueOf(name).Convert(restType.Key()), value)
+        }
+
+        return rest, nil
+    }
+
+    //named slice holds values by names, other values are held by indexes
+    for i, i_max := 0, from.Len(); i < i_max; i++ {
+        if !indexes[i] {
+            key, ok := t.plan.positions[i]
+            if !ok {
+                key = strconv.Itoa(i)
+            }
+
+            value, err := convertRest(from.Index(i), restType.Elem(), t.config.ValueConverter)
+            if err != nil {
+                return reflect.Value{}, err
+            }
+
+            rest.SetMapIndex(reflect.ValueOf(key).Convert(restType.Key()), value)
+        }
+    }
+
+    return rest, nil
+}
+
+// setRest merges data of rest with mode into positions or keys of to that are not linked to any field of struct t via compiled plan of t. Returns names or indexes of positions that were set.
+func setRest(t *mapperType, linkedType *mapperType, to *reflect.Value, rest reflect.Value, mode MergeMode) ([]string, error) {
+    indexes, names := t.plan.linkedIndexes, t.plan.linkedNames
+    var mapped []string
+
+    //name of position is a name of linked field or index
+    positionName := func(i int) (string) {
+        if name, ok := t.plan.positions[i]; ok {
+            return name
+        }
+
+        return strconv.Itoa(i)
+    }
+
+    if to.Kind() == reflect.Map {
+        mapMapper := (*MapMapper)(linkedType)
+
+        for _, key := range rest.MapKeys() {
+            if names[linkedType.fieldName(key.String())] {
+                continue
+            }
+
+            toKey, err := mapMapper.resolveKey(key.String())
+            if err != nil {
+                return nil, err
+            }
+
+            if !canMerge(to.MapIndex(toKey), rest.MapIndex(key), mode) {
+                continue
+            }
+
+            value, err := convertRest(rest.MapIndex(key), to.Type().Elem(), t.config.ValueConverter)
+            if err != nil {
+                return nil, err
+            }
+
+            to.SetMapIndex(toKey, value)
+            mapped = append(mapped, key.String())
+        }
+
+        return mapped, nil
+    }
+
+    if rest.Kind() == reflect.Slice {
+        //values fill not linked positions in order and the rest of values are appended
+        i := 0
+        for j, j_max := 0, rest.Len(); j < j_max; j++ {
+            for i < to.Len() && indexes[i] {
+                i++
+            }
+
+            var current reflect.Value
+            if i < to.Len() {
+                current = to.Index(i)
+            }
+
+            //skipped value still takes a position, so appended position gets zero value
+            if !canMerge(current, rest.Index(j), mode) {
+                if !current.IsValid() {
+                    if err := appendRest(to, reflect.Zero(to.Type().Elem())); err != nil {
+                        return nil, err
+                    }
+                }
+
+                i++
+                continue
+            }
+
+            value, err := convertRest(rest.Index(j), to.Type().Elem(), t.config.ValueConverter)
+            if err != nil {
+                return nil, err
+            }
+
+            if current.IsValid() {
+                current.Set(value)
+            } else if err := appendRest(to, value); err != nil {
+                return nil, err
+            }
+
+            mapped = append(mapped, positionName(i))
+            i++
+        }
+
+        return mapped, nil
+    }
+
+    for _, key := range rest.MapKeys() {
+        i := -1
+        if field, ok := linkedType.fields[linkedType.fieldName(key.String())]; ok {
+            i = field.id
+        } else if index, err := strconv.Atoi(key.String()); err == nil {
+            i = index
+        }
+
+        if i < 0 || indexes[i] {
+            continue
+        }
+
+        var current reflect.Value
+        if i < to.Len() {
+            current = to.Index(i)
+        }
+
+        if !canMerge(current, rest.MapIndex(key), mode) {
+            continue
+        }
+
+        value, err := convertRest(rest.MapIndex(key), to.Type().Elem(), t.config.ValueConverter)
+        if err != nil {
+            return nil, err
+        }
+
+        for to.Len() <= i {
+            if err := appendRest(to, reflect.Zero(to.Type().Elem())); err != nil {
+                return nil, err
+            }
+        }
+
+        to.Index(i).Set(value)
+        mapped = append(mapped, positionName(i))
+    }
+
+    return mapped, nil
+}
+
+// appendRest appends a value to slice and keeps slice addressable if it was. Array has fixed length, so value can't be placed.
+func appendRest(to *reflect.Value, value reflect.Value) (error) {
+    if to.Kind() == reflect.Array {
+        return errors.New(fmt.Sprintf("Can't place value '%v'. Array %s is full.", value.Interface(), to.Type()))
+    }
+
+    if appended := reflect.Append(*to, value); to.CanSet() {
+        to.Set(appended)
+    } else {
+        *to = appended
+    }
+
+    return nil
+}
+
+// convertRest converts a value of not linked data to required type toType via convert. Values are copied as is if it's possible.
+func convertRest(from reflect.Value, toType reflect.Type, convert ConvertFunc) (reflect.Value, error) {
+    if from.Kind() == reflect.Interface {
+        if from.IsNil() {
+            return reflect.Zero(toType), nil
+        }
+
+        from = from.Elem()
+    }
+
+    if from.Type().AssignableTo(toType) {
+        return from, nil
+    }
+
+    value, err := convert(from, toType)
+    if err != nil {
+        return reflect.Value{}, err
+    }
+
+    if !value.IsValid() {
+        return reflect.Zero(toType), nil
+    }
+
+    return value, nil
+}
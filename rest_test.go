@@ -0,0 +1,106 @@
+package remapper
+
+import (
+    "testing"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestRestSlice(t *testing.T) {
+    type TestStructRest struct {
+        IntVal int      `remapper:"int_val"`
+        StrVal string   `remapper:"str_val"`
+        Rest   []string `remapper:",rest"`
+    }
+
+    mapper, err := New(TestStructRest{}, Slice([]string{}, []string{"int_val", "unknown1", "str_val", "unknown2"}))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    s, err := mapper.Map([]string{"-1", "a", "test string", "b", "c"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructRest{-1, "test string", []string{"a", "b", "c"}}, s)
+
+    a, err := mapper.Map(s)
+    require.Nil(t, err)
+    assert.Equal(t, []string{"-1", "a", "test string", "b", "c"}, a)
+
+    //not linked positions are merged with mode too
+    arr := []string{"", "x", "", "", ""}
+    require.Nil(t, mapper.WithMerge(MergeZero).MapInto(s, &arr))
+    assert.Equal(t, []string{"-1", "x", "test string", "b", "c"}, arr)
+
+    arr = []string{"0", "x", "y", "z"}
+    require.Nil(t, mapper.WithMerge(MergeNonEmpty).MapInto(TestStructRest{0, "test string", []string{"", "b", "c"}}, &arr))
+    assert.Equal(t, []string{"0", "x", "test string", "b", "c"}, arr)
+
+    arr = []string{"0"}
+    require.Nil(t, mapper.WithMerge(MergeNonEmpty).MapInto(TestStructRest{0, "", []string{"", "b"}}, &arr))
+    assert.Equal(t, []string{"0", "", "", "b"}, arr)
+}
+
+func TestRestSliceByNames(t *testing.T) {
+    type TestStructRest struct {
+        IntVal int               `remapper:"int_val"`
+        Rest   map[string]string `remapper:",rest"`
+    }
+
+    mapper, err := New(Slice([]interface{}{}, []string{"int_val", "Unknown"}), TestStructRest{})
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    s, err := mapper.Map([]interface{}{-1, "a", 10})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructRest{-1, map[string]string{"Unknown": "a", "2": "10"}}, s)
+
+    a, err := mapper.Map(s)
+    require.Nil(t, err)
+    assert.Equal(t, []interface{}{-1, "a", "10"}, a)
+}
+
+func TestRestMap(t *testing.T) {
+    type TestStructRest struct {
+        IntVal int               `remapper:"int_val"`
+        Rest   map[string]string `remapper:",rest"`
+    }
+
+    mapper, err := New(TestStructRest{}, Map(map[string]string{}, []string{"int_val"}))
+    require.Nil(t, err)
+    require.NotNil(t, mapper)
+
+    s, err := mapper.Map(map[string]string{"int_val": "-1", "unknown": "a"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructRest{-1, map[string]string{"unknown": "a"}}, s)
+
+    m, err := mapper.Map(TestStructRest{-1, map[string]string{"unknown": "a", "INT_VAL": "0"}})
+    require.Nil(t, err)
+    assert.Equal(t, map[string]string{"int_val": "-1", "unknown": "a"}, m)
+
+    //not linked keys are merged with mode too
+    existing := map[string]string{"unknown": "old", "empty": ""}
+    require.Nil(t, mapper.WithMerge(MergeZero).MapInto(TestStructRest{-1, map[string]string{"unknown": "a", "empty": "b", "other": "c"}}, &existing))
+    assert.Equal(t, map[string]string{"int_val": "-1", "unknown": "old", "empty": "b", "other": "c"}, existing)
+
+    existing = map[string]string{"unknown": "old"}
+    require.Nil(t, mapper.WithMerge(MergeNonEmpty).MapInto(TestStructRest{-1, map[string]string{"unknown": "", "other": "c"}}, &existing))
+    assert.Equal(t, map[string]string{"int_val": "-1", "unknown": "old", "other": "c"}, existing)
+}
+
+func TestRestInvalid(t *testing.T) {
+    type TestStructRestType struct {
+        Rest []string `remapper:",rest"`
+    }
+
+    mapper, err := New(TestStructRestType{}, Map(map[string]string{}, []string{"int_val"}))
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+
+    type TestStructRestMultiple struct {
+        Rest1 []string `remapper:",rest"`
+        Rest2 []string `remapper:",rest"`
+    }
+
+    mapper, err = New(TestStructRestMultiple{}, Slice([]string{}, []string{"int_val"}))
+    assert.NotNil(t, err)
+    assert.Nil(t, mapper)
+}
@@ -2,6 +2,7 @@ package remapper
 
 import (
     "errors"
+    "fmt"
     "reflect"
     "strconv"
 )
@@ -15,17 +16,50 @@ import (
 // also additionally slice can be:
 // - typed: i.e. slice has type, e.g. []string
 // - untyped: i.e. slice has no any type, e.g. []interface{}
+//
+// array, e.g. [8]string, is mapped same way as slice, but has fixed length, so any index must be in range of array.
 type SliceMapper mapperType
 
+// SliceOptions holds settings of slice that can be used instead of names or length at 'Slice'
+type SliceOptions struct {
+    // Names of fields for 'named' slice
+    Names []string
+
+    // Width is a length of a new slice. Linking of fields with indexes out of width is not allowed.
+    // Omit it to use the highest linked index to get a length of a new slice.
+    Width int
+
+    // Placeholder is a value for positions of a new slice that were not linked. Default: zero value of element
+    Placeholder interface{}
+}
+
 // newSliceMapper creates a new SliceMapper that configured with options to map from/to slice
 //
 // - for 'named' slice you must provide a 'field names' via []string. E.g.: []string{"first_name", "birthday",...}
-// - for 'indexed' slice you can provide a length of slice. Omit it if you are going only to convert from this type of slice.
-func newSliceMapper(dataType reflect.Type, normalizedType reflect.Type, options interface{}) (mapperType) {
+// - for 'indexed' slice you can provide a length of slice. Omit it to use the highest linked index as length.
+// - for any slice you can provide SliceOptions with names, length and placeholder for not linked positions.
+func newSliceMapper(dataType reflect.Type, normalizedType reflect.Type, options interface{}, config *Config) (mapperType, error) {
     m := SliceMapper{
         fields:         map[string]*mapperField{},
         dataType:       dataType,
         normalizedType: normalizedType,
+        config:         config,
+    }
+
+    //is slice with settings?
+    if sliceOptions, ok := options.(SliceOptions); ok {
+        if sliceOptions.Placeholder != nil {
+            m.placeholder = reflect.ValueOf(sliceOptions.Placeholder)
+            if !m.placeholder.Type().AssignableTo(normalizedType.Elem()) {
+                return mapperType{}, errors.New(fmt.Sprintf("Invalid type '%s' of placeholder for %v", m.placeholder.Type(), normalizedType))
+            }
+        }
+
+        m.width = sliceOptions.Width
+        options = nil
+        if sliceOptions.Names != nil {
+            options = sliceOptions.Names
+        }
     }
 
     if options != nil {
@@ -33,10 +67,14 @@ func newSliceMapper(dataType reflect.Type, normalizedType reflect.Type, options
         if arrayLen, isIndexedArray := options.(int); isIndexedArray {
             for fieldIndex := 0; fieldIndex < arrayLen; fieldIndex++ {
                 fieldName := strconv.FormatInt(int64(fieldIndex), 10)
-                m.fields[NameMapper(fieldName)] = &mapperField{
+                err := (*mapperType)(&m).addField(fieldName, &mapperField{
                     id:        fieldIndex,
-                    convert:   ValueConverter,
+                    convert:   config.ValueConverter,
                     reverseId: -1,
+                })
+
+                if err != nil {
+                    return mapperType{}, err
                 }
             }
         } else {
@@ -45,37 +83,74 @@ func newSliceMapper(dataType reflect.Type, normalizedType reflect.Type, options
             if fieldNames.Kind() == reflect.Slice || fieldNames.Kind() == reflect.Array {
                 for fieldIndex, totalFields := 0, fieldNames.Len(); fieldIndex < totalFields; fieldIndex += 1 {
                     fieldName := fieldNames.Index(fieldIndex).Interface().(string)
-                    m.fields[NameMapper(fieldName)] = &mapperField{
+                    err := (*mapperType)(&m).addField(fieldName, &mapperField{
                         id:        fieldIndex,
-                        convert:   ValueConverter,
+                        convert:   config.ValueConverter,
                         reverseId: -1,
+                    })
+
+                    if err != nil {
+                        return mapperType{}, err
                     }
                 }
             }
         }
     }
 
+    if len(m.fields) > m.width {
+        m.width = len(m.fields)
+    }
+
+    //array has fixed length
+    if normalizedType.Kind() == reflect.Array {
+        if m.width > normalizedType.Len() {
+            return mapperType{}, errors.New(fmt.Sprintf("Width %d is out of range of %v", m.width, normalizedType))
+        }
+
+        m.width = normalizedType.Len()
+    }
+
     m.mapperTypeI = &m
-    return mapperType(m)
+    return mapperType(m), nil
 }
 
 // creates a new instance of slice of required type.
-// N.B.: It's not possible to create an indexed slice without length - see options for 'indexed' slices
+// N.B.: Length of slice is a width of slice or the highest linked index, so it's not possible to create an indexed slice without any linked fields.
 func (m *SliceMapper) create() (reflect.Value, error) {
-    arrLen := len(m.fields)
+    arrLen := m.width
+
+    for _, field := range m.fields {
+        if field.id >= arrLen {
+            arrLen = field.id + 1
+        }
+    }
 
     if arrLen == 0 {
         return reflect.Value{}, errors.New("Can't get length of a new slice to create.")
     }
 
-    return reflect.MakeSlice(m.dataType, arrLen, arrLen), nil
+    to := reflect.New(m.normalizedType).Elem()
+    if m.normalizedType.Kind() == reflect.Slice {
+        to.Set(reflect.MakeSlice(m.normalizedType, arrLen, arrLen))
+    }
+
+    if m.placeholder.IsValid() {
+        for i := 0; i < arrLen; i++ {
+            to.Index(i).Set(m.placeholder)
+        }
+    }
+
+    return to, nil
 }
 
 // sets a value to a slice at i index
-func (m *SliceMapper) set(to reflect.Value, i int, name string, value reflect.Value) {
-    if i < to.Len() {
-        to.Index(i).Set(value)
+func (m *SliceMapper) set(to reflect.Value, i int, name string, value reflect.Value) (error) {
+    if i >= to.Len() {
+        return errors.New(fmt.Sprintf("Can't set value for '%s'. Index %d is out of range of slice with length %d.", name, i, to.Len()))
     }
+
+    to.Index(i).Set(value)
+    return nil
 }
 
 // gets a value from a slice at i index
@@ -86,3 +161,14 @@ func (m *SliceMapper) get(from reflect.Value, i int, name string) (reflect.Value
 
     return reflect.Value{}
 }
+
+// isNamed returns true if slice has names of fields, i.e. not only indexes
+func (m *SliceMapper) isNamed() (bool) {
+    for _, field := range m.fields {
+        if field.name != strconv.Itoa(field.id) {
+            return true
+        }
+    }
+
+    return false
+}
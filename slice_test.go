@@ -3,6 +3,7 @@ package remapper
 import (
     "testing"
     "reflect"
+    "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/require"
 )
 
@@ -21,12 +22,14 @@ func TestSliceMapper(t *testing.T) {
     require.Nil(t, err)
     require.Equal(t, reflect.Slice, dataNormalizedType.Kind())
 
-    mapper := newSliceMapper(dataType, dataNormalizedType, namedSliceNames)
+    mapper, err := newSliceMapper(dataType, dataNormalizedType, namedSliceNames, Config{}.resolve())
+    require.Nil(t, err)
     testTypedSliceMethods(t, mapper, dataVal)
 
     //indexed-typed array
     data = []string{"", "", "", "", ""}
-    mapper = newSliceMapper(dataType, dataNormalizedType, indexedArrayLen)
+    mapper, err = newSliceMapper(dataType, dataNormalizedType, indexedArrayLen, Config{}.resolve())
+    require.Nil(t, err)
     testTypedSliceMethods(t, mapper, dataVal)
 
     //named-untyped array
@@ -39,15 +42,57 @@ func TestSliceMapper(t *testing.T) {
     require.Nil(t, err)
     require.Equal(t, reflect.Slice, dataNormalizedType.Kind())
 
-    mapper = newSliceMapper(dataType, dataNormalizedType, namedSliceNames)
+    mapper, err = newSliceMapper(dataType, dataNormalizedType, namedSliceNames, Config{}.resolve())
+    require.Nil(t, err)
     testUntypedSliceMethods(t, mapper, data1Val)
 
     //indexed-untyped array
     data1 = []interface{}{int(0), uint(0), "", 0.0, false}
-    mapper = newSliceMapper(dataType, dataNormalizedType, indexedArrayLen)
+    mapper, err = newSliceMapper(dataType, dataNormalizedType, indexedArrayLen, Config{}.resolve())
+    require.Nil(t, err)
     testUntypedSliceMethods(t, mapper, data1Val)
 }
 
+func TestArrayMapper(t *testing.T) {
+    namedSliceNames := []string{"IntVal", "UintVal", "StrVal", "FloatVal", "BoolVal"}
+
+    data := [5]string{}
+    dataVal := reflect.Indirect(reflect.ValueOf(&data))
+
+    //named-typed array
+    dataType := reflect.TypeOf(data)
+    require.Equal(t, reflect.Array, dataType.Kind())
+
+    dataNormalizedType, err := resolveType(data, reflect.Slice, reflect.Array)
+    require.Nil(t, err)
+    require.Equal(t, reflect.Array, dataNormalizedType.Kind())
+
+    mapper, err := newSliceMapper(dataType, dataNormalizedType, namedSliceNames, Config{}.resolve())
+    require.Nil(t, err)
+    testTypedSliceMethods(t, mapper, dataVal)
+
+    //indexed-typed array
+    data = [5]string{}
+    mapper, err = newSliceMapper(dataType, dataNormalizedType, nil, Config{}.resolve())
+    require.Nil(t, err)
+    assert.Equal(t, 5, mapper.width)
+    testTypedSliceMethods(t, mapper, dataVal)
+
+    //too many names
+    _, err = newSliceMapper(dataType, dataNormalizedType, append(namedSliceNames, "Other"), Config{}.resolve())
+    assert.NotNil(t, err)
+}
+
+func TestSliceMapperCollision(t *testing.T) {
+    data := []string{}
+    dataNormalizedType, err := resolveType(data, reflect.Slice)
+    require.Nil(t, err)
+
+    _, err = newSliceMapper(reflect.TypeOf(data), dataNormalizedType, []string{"Name", "name"}, Config{}.resolve())
+    require.NotNil(t, err)
+    assert.Contains(t, err.Error(), "'name' collides with 'Name'")
+}
+
 func testTypedSliceMethods(t *testing.T, mapper mapperType, target reflect.Value) {
     require.NotNil(t, mapper)
     require.IsType(t, mapperType{}, mapper)
@@ -0,0 +1,407 @@
+package remapper
+
+import (
+    "errors"
+    "fmt"
+    "reflect"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+
+    "github.com/plandem/remapper/internal/tags"
+)
+
+// Spec is a serializable configuration of mapper, e.g. to load mapping from JSON via encoding/json.
+//
+//    {
+//        "types": [
+//            {"type": "user"},
+//            {"type": "[]string", "names": ["user_id", "name"]}
+//        ],
+//        "links": [
+//            {"field": "ID", "to": "user_id"},
+//            {"field": "Name", "to": "name", "options": ["required"], "converter": "trim"}
+//        ]
+//    }
+type Spec struct {
+    // Types of mapper. Type is resolved via name that was registered via 'RegisterType'.
+    Types [2]TypeSpec `json:"types"`
+
+    // Links between fields of types
+    Links []LinkSpec `json:"links"`
+
+    // Converter is a name of converter for values that was registered via 'RegisterConverter'. Default: converter of Config
+    Converter string `json:"converter,omitempty"`
+}
+
+// TypeSpec is a serializable settings of type of mapper
+type TypeSpec struct {
+    // Type is a name of type that was registered via 'RegisterType', e.g. 'user' or '[]string'
+    Type string `json:"type"`
+
+    // Names of fields for named slice or map
+    Names []string `json:"names,omitempty"`
+
+    // Width of indexed slice. See SliceOptions
+    Width int `json:"width,omitempty"`
+
+    // Placeholder is a value for not linked positions of a new slice. See SliceOptions
+    // Decoded value is converted to type of elements, so numbers of JSON are stored as float64 into untyped slice.
+    Placeholder interface{} `json:"placeholder,omitempty"`
+
+    // ValueType is a name of type of values to store into untyped map that was registered via 'RegisterType', e.g. 'string'. See MapOptions
+    ValueType string `json:"value_type,omitempty"`
+
+    // CaseSensitive enables matching of keys of map as is. See MapOptions
+    CaseSensitive bool `json:"case_sensitive,omitempty"`
+}
+
+// LinkSpec is a serializable link between field of one type and field of other type
+type LinkSpec struct {
+    // Field is a name of field at first type or at second type for reverse link
+    Field string `json:"field"`
+
+    // To is a name or an index of linked field at other type, e.g. 'user_id' or '3'. Names of few linked fields are joined via '+' for combined field.
+    To string `json:"to"`
+
+    // Reverse is true if Field is a field of second type, e.g. for index at first type
+    Reverse bool `json:"reverse,omitempty"`
+
+    // Options of link, e.g. 'omit', 'required', 'rest' or 'join=-'
+    Options []string `json:"options,omitempty"`
+
+    // Converter is a name of converter for values of link that was registered via 'RegisterConverter'. Default: converter of mapper
+    Converter string `json:"converter,omitempty"`
+}
+
+var (
+    specMu         sync.RWMutex
+    specTypes      = map[string]interface{}{}
+    specConverters = map[string]ConvertFunc{}
+)
+
+func init() {
+    for _, t := range []interface{}{[]string{}, []interface{}{}, map[string]string{}, map[string]interface{}{}, "", 0, int64(0), 0.0, false} {
+        RegisterType(reflect.TypeOf(t).String(), t)
+    }
+
+    RegisterConverter("remapper.Convert", Convert)
+}
+
+// RegisterType registers a data with name, so type of data can be used via name at Spec, e.g.: RegisterType("user", &User{}).
+// Types []string, []interface {}, map[string]string, map[string]interface {}, string, int, int64, float64 and bool are registered with own names.
+func RegisterType(name string, t interface{}) {
+    specMu.Lock()
+    defer specMu.Unlock()
+
+    specTypes[name] = t
+}
+
+// RegisterConverter registers a converter with name, so it can be used via name at Spec
+func RegisterConverter(name string, convert ConvertFunc) {
+    specMu.Lock()
+    defer specMu.Unlock()
+
+    specConverters[name] = convert
+}
+
+// getSpecType returns a data that was registered with name
+func getSpecType(name string) (interface{}, error) {
+    specMu.RLock()
+    defer specMu.RUnlock()
+
+    if t, ok := specTypes[name]; ok {
+        return t, nil
+    }
+
+    return nil, errors.New(fmt.Sprintf("Unknown type '%s'. You must register it via 'RegisterType'.", name))
+}
+
+// getSpecConverter returns a converter that was registered with name
+func getSpecConverter(name string) (ConvertFunc, error) {
+    specMu.RLock()
+    defer specMu.RUnlock()
+
+    if convert, ok := specConverters[name]; ok {
+        return convert, nil
+    }
+
+    return nil, errors.New(fmt.Sprintf("Unknown converter '%s'. You must register it via 'RegisterConverter'.", name))
+}
+
+// specTypeName returns a name that data type was registered with or name of type if it was not registered
+func specTypeName(dataType reflect.Type) (string) {
+    specMu.RLock()
+    defer specMu.RUnlock()
+
+    var names []string
+    for name, t := range specTypes {
+        if reflect.TypeOf(t) == dataType {
+            names = append(names, name)
+        }
+    }
+
+    if len(names) == 0 {
+        return dataType.String()
+    }
+
+    sort.Strings(names)
+    return names[0]
+}
+
+// specConverterName returns a name that converter was registered with
+func specConverterName(convert ConvertFunc) (string, error) {
+    specMu.RLock()
+    defer specMu.RUnlock()
+
+    var names []string
+    for name, registered := range specConverters {
+        if isSameConverter(registered, convert) {
+            names = append(names, name)
+        }
+    }
+
+    if len(names) == 0 {
+        return "", errors.New(fmt.Sprintf("Converter '%s' was not registered. You must register it via 'RegisterConverter'.", converterName(convert)))
+    }
+
+    sort.Strings(names)
+    return names[0], nil
+}
+
+// isSameConverter returns true if both converters are same function
+func isSameConverter(a ConvertFunc, b ConvertFunc) (bool) {
+    return a != nil && b != nil && reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+// NewFromSpec creates a new Mapper from spec. Config provides settings that can't be serialized, e.g. NameMapper.
+func NewFromSpec(spec Spec, config Config) (*Mapper, error) {
+    if len(spec.Converter) > 0 {
+        convert, err := getSpecConverter(spec.Converter)
+        if err != nil {
+            return nil, err
+        }
+
+        config.ValueConverter = convert
+    }
+
+    m := &Mapper{config: config.resolve(), merge: config.Merge, empty: config.Empty}
+    for _, typeSpec := range spec.Types {
+        typeOption, err := typeSpec.option()
+        if err != nil {
+            return nil, err
+        }
+
+        if err := typeOption(m); err != nil {
+            return nil, err
+        }
+    }
+
+    for _, link := range spec.Links {
+        fromType, toType := m.types[0], m.types[1]
+        if link.Reverse {
+            fromType, toType = toType, fromType
+        }
+
+        options := tags.Options(strings.Join(link.Options, ","))
+        if err := resolveMappingField(fromType, link.Field, toType, tags.Join(link.To, options)); err != nil {
+            return nil, err
+        }
+
+        if len(link.Converter) > 0 {
+            convert, err := getSpecConverter(link.Converter)
+            if err != nil {
+                return nil, err
+            }
+
+            //converter is used in both directions
+            field := fromType.fields[fromType.fieldName(link.Field)]
+            field.convert = convert
+
+            for _, toField := range toType.fields {
+                if field.reverseId >= 0 && toField.id == field.reverseId {
+                    toField.convert = convert
+                }
+            }
+        }
+    }
+
+    m.compile()
+    return m, nil
+}
+
+// option returns option to setup type of mapper
+func (s TypeSpec) option() (option, error) {
+    t, err := getSpecType(s.Type)
+    if err != nil {
+        return nil, err
+    }
+
+    normalizedType, err := resolveType(t, reflect.Struct, reflect.Slice, reflect.Array, reflect.Map)
+    if err != nil {
+        return nil, err
+    }
+
+    switch normalizedType.Kind() {
+    case reflect.Slice, reflect.Array:
+        placeholder := s.Placeholder
+        if placeholder != nil && !reflect.TypeOf(placeholder).AssignableTo(normalizedType.Elem()) {
+            //decoded value can have other type than element, e.g. float64 for numbers of JSON
+            if value, err := Convert(reflect.ValueOf(placeholder), normalizedType.Elem()); err == nil && value.IsValid() {
+                placeholder = value.Interface()
+            }
+        }
+
+        return Slice(t, SliceOptions{Names: s.Names, Width: s.Width, Placeholder: placeholder}), nil
+    case reflect.Map:
+        var valueType reflect.Type
+        if len(s.ValueType) > 0 {
+            value, err := getSpecType(s.ValueType)
+            if err != nil {
+                return nil, err
+            }
+
+            valueType = reflect.TypeOf(value)
+        }
+
+        return Map(t, MapOptions{Names: s.Names, ValueType: valueType, CaseSensitive: s.CaseSensitive}), nil
+    }
+
+    return Struct(t), nil
+}
+
+// Spec returns a configuration of mapper that can be serialized, e.g. to JSON, and loaded via 'NewFromSpec'.
+// Types that were not registered get names of own types and must be registered with these names to load spec.
+// Returns error if mapper has links that can't be described, e.g. fields of inlined structs or converters that were not registered.
+func (m *Mapper) Spec() (Spec, error) {
+    spec := Spec{}
+
+    for i, t := range m.types {
+        spec.Types[i] = t.spec()
+    }
+
+    if !isSameConverter(m.config.ValueConverter, Convert) {
+        name, err := specConverterName(m.config.ValueConverter)
+        if err != nil {
+            return Spec{}, err
+        }
+
+        spec.Converter = name
+    }
+
+    //links are described via struct, because struct holds options of combined and rest fields and few fields can be linked to same key of map
+    primary := 0
+    if m.types[0].normalizedType.Kind() != reflect.Struct && m.types[1].normalizedType.Kind() == reflect.Struct {
+        primary = 1
+    }
+
+    fromType, toType := m.types[primary], m.types[1-primary]
+    toFields := make(map[int]*mapperField, len(toType.fields))
+    for _, field := range toType.fields {
+        toFields[field.id] = field
+    }
+
+    for _, field := range sortedFields(fromType) {
+        if field.reverseId < 0 && field.combined == nil && !field.rest {
+            continue
+        }
+
+        if len(field.index) > 1 {
+            return Spec{}, errors.New(fmt.Sprintf("Field '%s' of inlined struct can't be described via spec.", field.name))
+        }
+
+        link := LinkSpec{Field: field.name, Reverse: primary == 1}
+        for _, option := range strings.Split(string(field.options), ",") {
+            if len(option) > 0 {
+                link.Options = append(link.Options, option)
+            }
+        }
+
+        switch {
+        case field.combined != nil && field.reverseId < 0:
+            names := make([]string, len(field.combined.names))
+            for i, name := range field.combined.names {
+                names[i] = toType.fields[name].name
+            }
+
+            link.To = strings.Join(names, combinedSeparator)
+        case field.combined != nil:
+            //link is described via combined field of other type
+            continue
+        case field.reverseId >= 0:
+            toField, ok := toFields[field.reverseId]
+            if !ok {
+                continue
+            }
+
+            link.To = toField.name
+
+            //index can't be a source of link, so link is described from other side
+            if _, err := strconv.Atoi(link.Field); err == nil {
+                if _, err := strconv.Atoi(link.To); err == nil {
+                    return Spec{}, errors.New(fmt.Sprintf("Link between indexes '%s' and '%s' can't be described via spec.", link.Field, link.To))
+                }
+
+                link.Field, link.To, link.Reverse = link.To, link.Field, !link.Reverse
+            }
+        }
+
+        if !isSameConverter(field.convert, m.config.ValueConverter) {
+            name, err := specConverterName(field.convert)
+            if err != nil {
+                return Spec{}, err
+            }
+
+            link.Converter = name
+        }
+
+        spec.Links = append(spec.Links, link)
+    }
+
+    return spec, nil
+}
+
+// spec returns a serializable settings of type
+func (t *mapperType) spec() (TypeSpec) {
+    s := TypeSpec{Type: specTypeName(t.dataType), CaseSensitive: t.caseSensitive}
+
+    switch mapper := t.mapperTypeI.(type) {
+    case *MapMapper:
+        for _, field := range sortedFields(t) {
+            s.Names = append(s.Names, field.name)
+        }
+
+        if t.valueType != nil {
+            s.ValueType = specTypeName(t.valueType)
+        }
+    case *SliceMapper:
+        if mapper.isNamed() {
+            for _, field := range sortedFields(t) {
+                s.Names = append(s.Names, field.name)
+            }
+        } else if t.normalizedType.Kind() == reflect.Slice {
+            s.Width = t.width
+        }
+
+        if t.placeholder.IsValid() {
+            s.Placeholder = t.placeholder.Interface()
+        }
+    }
+
+    return s
+}
+
+// sortedFields returns fields of t ordered by id
+func sortedFields(t *mapperType) ([]*mapperField) {
+    fields := make([]*mapperField, 0, len(t.fields))
+    for _, field := range t.fields {
+        fields = append(fields, field)
+    }
+
+    sort.Slice(fields, func(i, j int) bool {
+        return fields[i].id < fields[j].id
+    })
+
+    return fields
+}
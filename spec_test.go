@@ -0,0 +1,190 @@
+package remapper
+
+import (
+    "testing"
+    "encoding/json"
+    "reflect"
+    "strings"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestSpec(t *testing.T) {
+    type TestStructSpec struct {
+        ID   int               `remapper:"id,required"`
+        Name string            `remapper:"first_name+last_name,join= "`
+        Note string            `remapper:"note,omit"`
+        Rest map[string]string `remapper:",rest"`
+    }
+
+    RegisterType("spec_struct", &TestStructSpec{})
+    RegisterType("indexed_struct", TestStructIndexed{})
+    RegisterType("named_struct", TestStructNamed{})
+    RegisterConverter("upper", func(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
+        value, err := Convert(from, toType)
+        if err == nil && value.Kind() == reflect.String {
+            value = reflect.ValueOf(strings.ToUpper(value.String()))
+        }
+
+        return value, err
+    })
+
+    //export -> json -> load gives same mapping
+    for _, args := range [][]interface{}{
+        {Slice([]string{}, []string{"first_name", "id", "last_name", "note", "x"}), &TestStructSpec{}},
+        {TestStructIndexed{}, []string{}},
+        {[]interface{}{}, TestStructIndexed{}},
+        {TestStructIndexed{}, Map(map[string]string{}, MapOptions{Names: []string{"int", "str"}, CaseSensitive: true}), map[string]string{"IntVal": "int", "StrVal": "str"}},
+    } {
+        mapper, err := New(args...)
+        require.Nil(t, err)
+
+        spec, err := mapper.Spec()
+        require.Nil(t, err)
+
+        data, err := json.Marshal(spec)
+        require.Nil(t, err)
+
+        var loadedSpec Spec
+        require.Nil(t, json.Unmarshal(data, &loadedSpec))
+        assert.Equal(t, spec, loadedSpec)
+
+        loaded, err := NewFromSpec(loadedSpec, Config{})
+        require.Nil(t, err)
+        assert.Equal(t, mapper.Describe(), loaded.Describe())
+
+        exported, err := loaded.Spec()
+        require.Nil(t, err)
+        assert.Equal(t, spec, exported)
+    }
+
+    //placeholder of slice and type of values of map are exported
+    for _, test := range []struct {
+        args []interface{}
+        from interface{}
+    }{
+        {[]interface{}{TestStructIndexed{}, Slice([]string{}, SliceOptions{Width: 8, Placeholder: "?"})}, mappedIndexedStruct},
+        {[]interface{}{TestStructIndexed{}, Slice([]interface{}{}, SliceOptions{Width: 8, Placeholder: "-"})}, mappedIndexedStruct},
+        {[]interface{}{TestStructNamed{}, Map(map[string]interface{}{}, MapOptions{ValueType: reflect.TypeOf("")})}, mappedNamedStruct},
+    } {
+        mapper, err := New(test.args...)
+        require.Nil(t, err)
+
+        spec, err := mapper.Spec()
+        require.Nil(t, err)
+
+        data, err := json.Marshal(spec)
+        require.Nil(t, err)
+
+        var loadedSpec Spec
+        require.Nil(t, json.Unmarshal(data, &loadedSpec))
+
+        loaded, err := NewFromSpec(loadedSpec, Config{})
+        require.Nil(t, err)
+
+        expected, err := mapper.Map(test.from)
+        require.Nil(t, err)
+
+        actual, err := loaded.Map(test.from)
+        require.Nil(t, err)
+        assert.Equal(t, expected, actual)
+    }
+
+    mapper, err := New(TestStructIndexed{}, Slice([]string{}, SliceOptions{Width: 8, Placeholder: "?"}))
+    require.Nil(t, err)
+
+    spec, err := mapper.Spec()
+    require.Nil(t, err)
+    assert.Equal(t, "?", spec.Types[1].Placeholder)
+
+    mapper, err = New(TestStructNamed{}, Map(map[string]interface{}{}, MapOptions{ValueType: reflect.TypeOf("")}))
+    require.Nil(t, err)
+
+    spec, err = mapper.Spec()
+    require.Nil(t, err)
+    assert.Equal(t, "string", spec.Types[1].ValueType)
+
+    m, err := mapper.Map(mappedNamedStruct)
+    require.Nil(t, err)
+    assert.Equal(t, "1", m.(map[string]interface{})["uint_val"])
+
+    //links are described via struct
+    mapper, err = New(Slice([]string{}, []string{"first_name", "id", "last_name", "note", "x"}), &TestStructSpec{})
+    require.Nil(t, err)
+
+    spec, err = mapper.Spec()
+    require.Nil(t, err)
+    assert.Equal(t, Spec{
+        Types: [2]TypeSpec{{Type: "[]string", Names: []string{"first_name", "id", "last_name", "note", "x"}}, {Type: "spec_struct"}},
+        Links: []LinkSpec{
+            {Field: "ID", To: "id", Reverse: true, Options: []string{"required"}},
+            {Field: "Name", To: "first_name+last_name", Reverse: true, Options: []string{"join= "}},
+            {Field: "Note", To: "note", Reverse: true, Options: []string{"omit"}},
+            {Field: "Rest", Reverse: true, Options: []string{"rest"}},
+        },
+    }, spec)
+
+    //load from json with converters
+    var loadedSpec Spec
+    require.Nil(t, json.Unmarshal([]byte(`{
+        "types": [
+            {"type": "indexed_struct"},
+            {"type": "[]string", "width": 4}
+        ],
+        "links": [
+            {"field": "IntVal", "to": "0"},
+            {"field": "StrVal", "to": "2", "converter": "upper"},
+            {"field": "BoolVal", "to": "3"}
+        ]
+    }`), &loadedSpec))
+
+    mapper, err = NewFromSpec(loadedSpec, Config{})
+    require.Nil(t, err)
+
+    a, err := mapper.Map(TestStructIndexed{IntVal: 1, StrVal: "test string", BoolVal: true})
+    require.Nil(t, err)
+    assert.Equal(t, []string{"1", "", "TEST STRING", "true"}, a)
+
+    s, err := mapper.Map([]string{"1", "", "test string", "true"})
+    require.Nil(t, err)
+    assert.Equal(t, TestStructIndexed{IntVal: 1, StrVal: "TEST STRING", BoolVal: true}, s)
+
+    spec, err = mapper.Spec()
+    require.Nil(t, err)
+    assert.Equal(t, "upper", spec.Links[1].Converter)
+
+    loadedSpec.Converter = "upper"
+    mapper, err = NewFromSpec(loadedSpec, Config{})
+    require.Nil(t, err)
+
+    spec, err = mapper.Spec()
+    require.Nil(t, err)
+    assert.Equal(t, "upper", spec.Converter)
+    assert.Equal(t, "", spec.Links[1].Converter)
+
+    //invalid specs
+    for _, invalid := range []Spec{
+        {Types: [2]TypeSpec{{Type: "unknown"}, {Type: "[]string"}}},
+        {Types: [2]TypeSpec{{Type: "indexed_struct"}, {Type: "[]string"}}, Converter: "unknown"},
+        {Types: [2]TypeSpec{{Type: "indexed_struct"}, {Type: "[]string"}}, Links: []LinkSpec{{Field: "Unknown", To: "0"}}},
+        {Types: [2]TypeSpec{{Type: "indexed_struct"}, {Type: "[]string"}}, Links: []LinkSpec{{Field: "IntVal", To: "0", Converter: "unknown"}}},
+    } {
+        _, err := NewFromSpec(invalid, Config{})
+        assert.NotNil(t, err)
+    }
+
+    //not registered converter and inlined fields can't be exported
+    mapper, err = New(TestStructIndexed{}, []string{}, Config{ValueConverter: func(from reflect.Value, toType reflect.Type) (reflect.Value, error) {
+        return Convert(from, toType)
+    }})
+    require.Nil(t, err)
+
+    _, err = mapper.Spec()
+    assert.NotNil(t, err)
+
+    mapper, err = New(TestStructInline{}, Slice([]string{}, []string{"name", "billing_street", "billing_city", "shipping_street", "shipping_city"}))
+    require.Nil(t, err)
+
+    _, err = mapper.Spec()
+    assert.NotNil(t, err)
+}
@@ -1,30 +1,46 @@
 package remapper
 
 import (
+    "errors"
+    "fmt"
     "reflect"
+    "strconv"
+
+    "github.com/plandem/remapper/internal/tags"
 )
 
 // StructMapper is mapper to convert from/to struct
 type StructMapper mapperType
 
-// newStructMapper creates a new StructMapper to map from/to struct
-func newStructMapper(dataType reflect.Type, normalizedType reflect.Type) (mapperType) {
+// newStructMapper creates a new StructMapper with config to map from/to struct
+func newStructMapper(dataType reflect.Type, normalizedType reflect.Type, config *Config) (mapperType, error) {
     m := StructMapper{
         fields:         map[string]*mapperField{},
         dataType:       dataType,
         normalizedType: normalizedType,
+        config:         config,
     }
 
     for i, i_max := 0, normalizedType.NumField(); i < i_max; i++ {
-        m.fields[NameMapper(normalizedType.Field(i).Name)] = &mapperField{
+        //unexported fields can't be set, but embedded struct can hold exported fields to inline
+        if f := normalizedType.Field(i); len(f.PkgPath) > 0 && !f.Anonymous {
+            continue
+        }
+
+        err := (*mapperType)(&m).addField(normalizedType.Field(i).Name, &mapperField{
             id:        i,
-            convert:   ValueConverter,
+            index:     []int{i},
+            convert:   config.ValueConverter,
             reverseId: -1,
+        })
+
+        if err != nil {
+            return mapperType{}, err
         }
     }
 
     m.mapperTypeI = &m
-    return mapperType(m)
+    return mapperType(m), nil
 }
 
 // creates a new instance of struct of required type.
@@ -33,12 +49,153 @@ func (m *StructMapper) create() (reflect.Value, error) {
 }
 
 // sets a value to a field of struct with i index
-func (m *StructMapper) set(to reflect.Value, i int, name string, value reflect.Value) {
-    to.Field(i).Set(value)
+func (m *StructMapper) set(to reflect.Value, i int, name string, value reflect.Value) (error) {
+    field := m.field(to, i, name)
+    if !field.IsValid() {
+        return unknownFieldName(name)
+    }
+
+    field.Set(value)
+    return nil
 }
 
 // gets a value from field of struct with i index
 func (m *StructMapper) get(from reflect.Value, i int, name string) (reflect.Value) {
-    return from.Field(i)
+    return m.field(from, i, name)
+}
+
+// returns a field of struct with i index. Fields of inlined structs have id out of range of top-level fields, so full path will be used for them.
+func (m *StructMapper) field(v reflect.Value, i int, name string) (reflect.Value) {
+    if i < v.NumField() {
+        return v.Field(i)
+    }
+
+    if field, ok := m.fields[name]; ok {
+        return v.FieldByIndex(field.index)
+    }
+
+    return reflect.Value{}
+}
+
+// inline registers fields of nested struct that is held by field with fieldName and returns a mapping for them extracted from tags tagName.
+// Names of registered fields are 'fieldName.nestedName' and names of linked fields are prefixed with prefix.
+// For indexes a prefix is an offset that will be added to index, e.g.: 'prefix=4' maps nested index 0 to 4.
+func (m *StructMapper) inline(fieldName string, tagName string, prefix string) (map[string]string, error) {
+    field, ok := m.fields[fieldName]
+    if !ok {
+        return nil, unknownFieldName(fieldName)
+    }
+
+    nestedType := m.normalizedType.FieldByIndex(field.index).Type
+    if nestedType.Kind() != reflect.Struct {
+        return nil, errors.New(fmt.Sprintf("Only struct can be inlined, but field '%s' has type '%s'.", fieldName, nestedType))
+    }
+
+    mapping := make(map[string]string)
+    for i, i_max := 0, nestedType.NumField(); i < i_max; i++ {
+        f := nestedType.Field(i)
+        if len(f.PkgPath) > 0 && !f.Anonymous {
+            continue
+        }
+
+        nestedName := (*mapperType)(m).fieldName(field.name + "." + f.Name)
+
+        nestedIndex := make([]int, len(field.index), len(field.index)+1)
+        copy(nestedIndex, field.index)
+
+        err := (*mapperType)(m).addField(field.name+"."+f.Name, &mapperField{
+            id:        m.nextId(),
+            index:     append(nestedIndex, i),
+            convert:   m.config.ValueConverter,
+            reverseId: -1,
+            inlined:   inlinedOptions(prefix),
+        })
+
+        if err != nil {
+            return nil, err
+        }
+
+        fromTag, err := fieldTag(f, tagName, m.config.Profile)
+        if err != nil {
+            return nil, err
+        }
+
+        if len(fromTag) == 0 {
+            continue
+        }
+
+        name, options := tags.Parse(fromTag)
+        if options.Contains("inline") {
+            nestedPrefix, _ := options.Value("prefix")
+            nestedPrefix, err := prefixName(prefix, nestedPrefix)
+            if err != nil {
+                return nil, errors.New(fmt.Sprintf("Could not inline field '%s'. %s", nestedName, err.Error()))
+            }
+
+            nestedMapping, err := m.inline(nestedName, tagName, nestedPrefix)
+            if err != nil {
+                return nil, err
+            }
+
+            for from, to := range nestedMapping {
+                mapping[from] = to
+            }
+
+            continue
+        }
+
+        if len(name) == 0 {
+            continue
+        }
+
+        prefixed, err := prefixName(prefix, name)
+        if err != nil {
+            return nil, errors.New(fmt.Sprintf("Could not inline field '%s'. %s", nestedName, err.Error()))
+        }
+
+        mapping[nestedName] = tags.Join(prefixed, options)
+    }
+
+    return mapping, nil
+}
+
+// nextId returns an id for a new field of inlined struct
+func (m *StructMapper) nextId() (int) {
+    id := m.normalizedType.NumField()
+
+    for _, field := range m.fields {
+        if field.id >= id {
+            id = field.id + 1
+        }
+    }
+
+    return id
+}
+
+// inlinedOptions returns options of struct field that inlines fields with prefix
+func inlinedOptions(prefix string) (tags.Options) {
+    if len(prefix) == 0 {
+        return "inline"
+    }
+
+    return tags.Options("inline,prefix=" + prefix)
+}
+
+// prefixName returns a name with prefix or an index with offset. Returns error if name is an index, but prefix is not a number.
+func prefixName(prefix string, name string) (string, error) {
+    if index, err := strconv.Atoi(name); err == nil {
+        if len(prefix) == 0 {
+            return name, nil
+        }
+
+        offset, err := strconv.Atoi(prefix)
+        if err != nil {
+            return "", errors.New(fmt.Sprintf("Prefix '%s' must be a number to offset index %d.", prefix, index))
+        }
+
+        return strconv.Itoa(index + offset), nil
+    }
+
+    return prefix + name, nil
 }
 
@@ -3,6 +3,7 @@ package remapper
 import (
     "testing"
     "reflect"
+    "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/require"
 )
 
@@ -29,7 +30,8 @@ func TestStructMapper(t *testing.T) {
     require.Nil(t, err)
     require.Equal(t, reflect.Struct, dataNormalizedType.Kind())
 
-    mapper := newStructMapper(dataType, dataNormalizedType)
+    mapper, err := newStructMapper(dataType, dataNormalizedType, Config{}.resolve())
+    require.Nil(t, err)
     testStructMethods(t, mapper, dataVal)
 
     //pointer to struct
@@ -40,12 +42,28 @@ func TestStructMapper(t *testing.T) {
     require.Nil(t, err)
     require.Equal(t, reflect.Struct, dataNormalizedType.Kind())
 
-    mapper = newStructMapper(dataType, dataNormalizedType)
+    mapper, err = newStructMapper(dataType, dataNormalizedType, Config{}.resolve())
+    require.Nil(t, err)
     testStructMethods(t, mapper, pDataVal)
 
     //typed struct is possible, so no tests for it
 }
 
+func TestStructMapperCollision(t *testing.T) {
+    type MyStruct struct {
+        ID int
+        Id int
+    }
+
+    data := MyStruct{}
+    dataNormalizedType, err := resolveType(data, reflect.Struct)
+    require.Nil(t, err)
+
+    _, err = newStructMapper(reflect.TypeOf(data), dataNormalizedType, Config{}.resolve())
+    require.NotNil(t, err)
+    assert.Contains(t, err.Error(), "'Id' collides with 'ID'")
+}
+
 func testStructMethods(t *testing.T, mapper mapperType, target reflect.Value) {
     require.NotNil(t, mapper)
     require.IsType(t, mapperType{}, mapper)